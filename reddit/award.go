@@ -0,0 +1,37 @@
+package reddit
+
+// Award is an award given to a post or comment.
+type Award struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type of the award, e.g. global, community.
+	Type    string `json:"award_type,omitempty"`
+	IconURL string `json:"icon_url,omitempty"`
+
+	// Price of the award, in coins.
+	CoinPrice int `json:"coin_price"`
+	// Number of times the award was given.
+	Count int `json:"count"`
+}
+
+// AwardSummary returns the number of times each award was given across the posts and
+// comments of the listing, keyed by award name.
+func AwardSummary(t things) map[string]int {
+	summary := make(map[string]int)
+
+	add := func(awardings []*Award) {
+		for _, award := range awardings {
+			summary[award.Name] += award.Count
+		}
+	}
+
+	for _, post := range t.Posts {
+		add(post.Awardings)
+	}
+	for _, comment := range t.Comments {
+		add(comment.Awardings)
+	}
+
+	return summary
+}
@@ -0,0 +1,39 @@
+package reddit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwardSummary(t *testing.T) {
+	tt := things{
+		Posts: []*Post{
+			{
+				FullID: "t3_post1",
+				Awardings: []*Award{
+					{Name: "Gold", Count: 2},
+					{Name: "Silver", Count: 1},
+				},
+			},
+			{FullID: "t3_post2"},
+		},
+		Comments: []*Comment{
+			{
+				FullID: "t1_comment1",
+				Awardings: []*Award{
+					{Name: "Silver", Count: 3},
+					{Name: "Wholesome", Count: 1},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, map[string]int{
+		"Gold":      2,
+		"Silver":    4,
+		"Wholesome": 1,
+	}, AwardSummary(tt))
+
+	require.Empty(t, AwardSummary(things{}))
+}
@@ -0,0 +1,486 @@
+package reddit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+type sortConfig struct {
+	StickiedFirst bool
+}
+
+// SortOpt is a configuration option to configure how comments are sorted.
+type SortOpt func(*sortConfig)
+
+// SortStickiedFirst sets whether stickied comments are placed before all others,
+// regardless of the sort. This is the default.
+func SortStickiedFirst(v bool) SortOpt {
+	return func(c *sortConfig) {
+		c.StickiedFirst = v
+	}
+}
+
+// SortComments sorts the comments and all their replies in place.
+// By default, stickied comments are placed first; use SortStickiedFirst to change that.
+// sort must be one of: best (or confidence), top, new, old, controversial.
+// Any other sort leaves the comments in the order Reddit returned them.
+func SortComments(comments []*Comment, sort string, opts ...SortOpt) {
+	sortConfig := &sortConfig{
+		StickiedFirst: true,
+	}
+	for _, opt := range opts {
+		opt(sortConfig)
+	}
+
+	less := commentSorts[sort]
+	sortComments(comments, less, sortConfig.StickiedFirst)
+}
+
+var commentSorts = map[string]func(a, b *Comment) bool{
+	"best":       bestCommentLess,
+	"confidence": bestCommentLess,
+	"top": func(a, b *Comment) bool {
+		return a.Score > b.Score
+	},
+	"new": func(a, b *Comment) bool {
+		return timeOf(a.Created).After(timeOf(b.Created))
+	},
+	"old": func(a, b *Comment) bool {
+		return timeOf(a.Created).Before(timeOf(b.Created))
+	},
+	// Reddit only tells us whether a comment is controversial, so the
+	// controversial ones come first, and ties are broken by score.
+	"controversial": func(a, b *Comment) bool {
+		if a.Controversiality != b.Controversiality {
+			return a.Controversiality > b.Controversiality
+		}
+		return a.Score > b.Score
+	},
+}
+
+func bestCommentLess(a, b *Comment) bool {
+	return wilsonScore(approximateVotes(a)) > wilsonScore(approximateVotes(b))
+}
+
+// approximateVotes estimates the number of upvotes and downvotes of a comment.
+// Reddit doesn't expose them, only the score and a controversiality flag, so this assumes
+// that a score is made of upvotes only (or downvotes only if negative), and that
+// a controversial comment received about as many extra upvotes as downvotes.
+// If the score is hidden, the comment is assumed to only have its author's upvote.
+func approximateVotes(c *Comment) (ups, downs float64) {
+	if c.ScoreHidden {
+		return 1, 0
+	}
+
+	score := float64(c.Score)
+	if score >= 0 {
+		ups = score
+	} else {
+		downs = -score
+	}
+
+	if c.Controversiality > 0 {
+		n := math.Max(math.Abs(score), 1)
+		ups += n
+		downs += n
+	}
+
+	return ups, downs
+}
+
+// wilsonScore returns the lower bound of the Wilson score confidence interval
+// for the proportion of upvotes, which is what Reddit's "best" sort is based on.
+// https://www.evanmiller.org/how-not-to-sort-by-average-rating.html
+func wilsonScore(ups, downs float64) float64 {
+	n := ups + downs
+	if n == 0 {
+		return 0
+	}
+
+	// z-score for an 80% confidence level, which is the one used by Reddit.
+	const z = 1.281551565545
+
+	p := ups / n
+	return (p + z*z/(2*n) - z*math.Sqrt((p*(1-p)+z*z/(4*n))/n)) / (1 + z*z/n)
+}
+
+func sortComments(comments []*Comment, less func(a, b *Comment) bool, stickiedFirst bool) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		if stickiedFirst && a.Stickied != b.Stickied {
+			return a.Stickied
+		}
+		if less == nil {
+			return false
+		}
+		return less(a, b)
+	})
+
+	for _, comment := range comments {
+		sortComments(comment.Replies.Comments, less, stickiedFirst)
+	}
+}
+
+// DecodePostAndCommentsSorted decodes a post and its comments, like PostAndComments.UnmarshalJSON,
+// and then sorts the comments according to the post's suggested sort.
+func DecodePostAndCommentsSorted(data []byte) (*PostAndComments, error) {
+	pc := new(PostAndComments)
+	if err := json.Unmarshal(data, pc); err != nil {
+		return nil, err
+	}
+
+	if pc.Post != nil {
+		SortComments(pc.Comments, pc.Post.SuggestedSort)
+	}
+
+	return pc, nil
+}
+
+// ThreadToMarkdown renders the post and its comment tree as Markdown.
+// Replies are nested using blockquotes, and comments written by the
+// author of the post are tagged with (OP).
+func ThreadToMarkdown(pc *PostAndComments) string {
+	if pc == nil || pc.Post == nil {
+		return ""
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "# %s\n\n", pc.Post.Title)
+	fmt.Fprintf(b, "*posted by u/%s in %s*\n", pc.Post.Author, pc.Post.SubredditNamePrefixed)
+	if pc.Post.Body != "" {
+		fmt.Fprintf(b, "\n%s\n", pc.Post.Body)
+	}
+
+	for _, comment := range pc.Comments {
+		renderComment(b, comment, 0)
+	}
+
+	return b.String()
+}
+
+func renderComment(b *strings.Builder, c *Comment, depth int) {
+	prefix := strings.Repeat("> ", depth)
+
+	header := fmt.Sprintf("**u/%s**", c.Author)
+	if c.IsSubmitter {
+		header += " (OP)"
+	}
+	header += fmt.Sprintf(" · %d points", c.Score)
+
+	fmt.Fprintf(b, "%s\n", strings.TrimSpace(prefix))
+	fmt.Fprintf(b, "%s%s\n", prefix, header)
+	fmt.Fprintf(b, "%s\n", strings.TrimSpace(prefix))
+	for _, line := range strings.Split(c.Body, "\n") {
+		fmt.Fprintf(b, "%s%s\n", prefix, line)
+	}
+
+	for _, reply := range c.Replies.Comments {
+		renderComment(b, reply, depth+1)
+	}
+}
+
+// ForestEqual reports whether two comment trees have the same structure, i.e. the same
+// comments (compared by full ID) with the same replies, in the same order.
+// Other fields, such as the score or body of a comment, are not compared.
+func ForestEqual(a, b []*Comment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].FullID != b[i].FullID {
+			return false
+		}
+		if !ForestEqual(a[i].Replies.Comments, b[i].Replies.Comments) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WalkBFS traverses the comment and its replies breadth-first, i.e. level by level,
+// calling fn with each comment and its level, starting at 0 for the receiver.
+// The traversal stops as soon as fn returns false.
+func (c *Comment) WalkBFS(fn func(*Comment, int) bool) {
+	type node struct {
+		comment *Comment
+		level   int
+	}
+
+	queue := []node{{c, 0}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		if !fn(n.comment, n.level) {
+			return
+		}
+
+		for _, reply := range n.comment.Replies.Comments {
+			queue = append(queue, node{reply, n.level + 1})
+		}
+	}
+}
+
+// Walk traverses the comment and its replies depth-first, calling fn with the receiver
+// first, then with each of its replies and their own replies, in order.
+// The traversal stops as soon as fn returns false.
+// It uses an explicit stack rather than recursion, so very deep threads are handled fine.
+func (c *Comment) Walk(fn func(*Comment) bool) {
+	stack := []*Comment{c}
+	for len(stack) > 0 {
+		comment := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !fn(comment) {
+			return
+		}
+
+		// push the replies in reverse so that the first one is visited first
+		replies := comment.Replies.Comments
+		for i := len(replies) - 1; i >= 0; i-- {
+			stack = append(stack, replies[i])
+		}
+	}
+}
+
+// Flatten returns all the comments of the post in reading order, i.e. depth-first,
+// with each comment followed by its replies. The comment tree is not modified.
+func (pc *PostAndComments) Flatten() []*Comment {
+	var comments []*Comment
+	for _, comment := range pc.Comments {
+		comment.Walk(func(c *Comment) bool {
+			comments = append(comments, c)
+			return true
+		})
+	}
+	return comments
+}
+
+// FindReply returns the reply with the given full ID, at any depth below the comment,
+// or nil if there isn't one.
+func (c *Comment) FindReply(fullID string) *Comment {
+	return findComment(c.Replies.Comments, fullID)
+}
+
+// FindComment returns the comment with the given full ID in the comment tree of the post,
+// or nil if there isn't one.
+func (pc *PostAndComments) FindComment(fullID string) *Comment {
+	return findComment(pc.Comments, fullID)
+}
+
+func findComment(comments []*Comment, fullID string) *Comment {
+	var found *Comment
+	for _, comment := range comments {
+		comment.Walk(func(c *Comment) bool {
+			if c.FullID == fullID {
+				found = c
+			}
+			return found == nil
+		})
+		if found != nil {
+			break
+		}
+	}
+	return found
+}
+
+// IsReplyToAuthor reports whether the comment replies to a comment, or the post itself,
+// written by the same author, e.g. when someone continues their own thought in a chain of replies.
+// The parent is looked up in the comment tree of the post, so it returns false if it isn't there.
+// Deleted comments are never considered replies to their own author.
+func (pc *PostAndComments) IsReplyToAuthor(c *Comment) bool {
+	if c.Author == "" || c.Author == "[deleted]" {
+		return false
+	}
+
+	if pc.Post != nil && c.ParentID == pc.Post.FullID {
+		return c.Author == pc.Post.Author
+	}
+
+	parent := pc.FindComment(c.ParentID)
+	return parent != nil && parent.Author == c.Author
+}
+
+// FlatComment is a comment along with its depth in the comment tree,
+// starting at 0 for top-level comments.
+type FlatComment struct {
+	Comment *Comment
+	Depth   int
+}
+
+// FlattenWithDepth is like Flatten, but also returns the depth of each comment,
+// e.g. to indent them when displaying the thread.
+func (pc *PostAndComments) FlattenWithDepth() []FlatComment {
+	var comments []FlatComment
+
+	// push the comments in reverse so that the first one is visited first
+	stack := make([]FlatComment, 0, len(pc.Comments))
+	for i := len(pc.Comments) - 1; i >= 0; i-- {
+		stack = append(stack, FlatComment{pc.Comments[i], 0})
+	}
+
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		comments = append(comments, c)
+
+		replies := c.Comment.Replies.Comments
+		for i := len(replies) - 1; i >= 0; i-- {
+			stack = append(stack, FlatComment{replies[i], c.Depth + 1})
+		}
+	}
+
+	return comments
+}
+
+// AuthorCommentCounts returns the number of comments each author wrote in the comment tree
+// of the post, at any depth. Deleted comments, whose author is [deleted], are left out.
+func (pc *PostAndComments) AuthorCommentCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, comment := range pc.Comments {
+		comment.WalkBFS(func(c *Comment, _ int) bool {
+			if c.Author != "" && c.Author != "[deleted]" {
+				counts[c.Author]++
+			}
+			return true
+		})
+	}
+	return counts
+}
+
+// ScorePercentile returns the percentage, from 0 to 100, of the other comments in the thread,
+// at any depth, with a lower score than the comment. Comments with the same score count for half.
+// If there are no other comments in the thread, it returns 100.
+func (pc *PostAndComments) ScorePercentile(c *Comment) float64 {
+	var others, below float64
+	for _, comment := range pc.Comments {
+		comment.Walk(func(other *Comment) bool {
+			if other == c {
+				return true
+			}
+			others++
+			switch {
+			case other.Score < c.Score:
+				below++
+			case other.Score == c.Score:
+				below += 0.5
+			}
+			return true
+		})
+	}
+
+	if others == 0 {
+		return 100
+	}
+	return below / others * 100
+}
+
+// EncodeThread writes the post and its comments to w in the same format as the Reddit API,
+// i.e. an array of 2 listings: the 1st one contains the post, the 2nd one its comments.
+// Each top-level comment is encoded and written on its own, so the whole thread is never
+// held in memory as JSON at once, which matters for very large threads.
+func EncodeThread(w io.Writer, pc *PostAndComments) error {
+	if pc == nil {
+		return errors.New("*PostAndComments: cannot be nil")
+	}
+
+	enc := json.NewEncoder(w)
+
+	write := func(s string) error {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+
+	writeThings := func(things []thing) error {
+		if err := write(`{"kind":"Listing","data":{"children":[`); err != nil {
+			return err
+		}
+		for i, t := range things {
+			if i > 0 {
+				if err := write(","); err != nil {
+					return err
+				}
+			}
+			if err := enc.Encode(t); err != nil {
+				return err
+			}
+		}
+		return write(`],"after":""}}`)
+	}
+
+	var posts []thing
+	if pc.Post != nil {
+		posts = append(posts, thing{Kind: kindPost, Data: pc.Post})
+	}
+
+	comments := make([]thing, 0, len(pc.Comments)+1)
+	for _, comment := range pc.Comments {
+		comments = append(comments, thing{Kind: kindComment, Data: comment})
+	}
+	if pc.More != nil {
+		comments = append(comments, thing{Kind: kindMore, Data: pc.More})
+	}
+
+	if err := write("["); err != nil {
+		return err
+	}
+	if err := writeThings(posts); err != nil {
+		return err
+	}
+	if err := write(","); err != nil {
+		return err
+	}
+	if err := writeThings(comments); err != nil {
+		return err
+	}
+	return write("]")
+}
+
+// TreeHash returns a hash of the post and its comment tree, covering the structure of the tree
+// as well as the body of the post and of each comment. It can be used to cheaply detect whether
+// anything changed between two fetches of the same post before diffing them.
+func (pc *PostAndComments) TreeHash() string {
+	h := sha256.New()
+
+	if pc.Post != nil {
+		writeHashField(h, pc.Post.FullID)
+		writeHashField(h, pc.Post.Body)
+	}
+	hashComments(h, pc.Comments)
+	hashMore(h, pc.More)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashComments(w io.Writer, comments []*Comment) {
+	// the number of comments delimits each level of the tree
+	writeHashField(w, fmt.Sprint(len(comments)))
+	for _, comment := range comments {
+		writeHashField(w, comment.FullID)
+		writeHashField(w, comment.Body)
+		hashComments(w, comment.Replies.Comments)
+		hashMore(w, comment.Replies.More)
+	}
+}
+
+func hashMore(w io.Writer, more *More) {
+	if more == nil {
+		writeHashField(w, "")
+		return
+	}
+	writeHashField(w, strings.Join(more.Children, ","))
+}
+
+// writeHashField writes s prefixed with its length, so that adjacent fields cannot be confused.
+func writeHashField(w io.Writer, s string) {
+	fmt.Fprintf(w, "%d:%s", len(s), s)
+}
@@ -0,0 +1,530 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestComment(id string, score int, created time.Time, replies ...*Comment) *Comment {
+	return &Comment{
+		ID:      id,
+		FullID:  kindComment + "_" + id,
+		Created: &Timestamp{created},
+		Score:   score,
+		Replies: Replies{Comments: replies},
+	}
+}
+
+func commentIDs(comments []*Comment) []string {
+	ids := make([]string, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestSortComments(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	newComments := func() []*Comment {
+		sticky := newTestComment("sticky", 1, day)
+		sticky.Stickied = true
+		controversial := newTestComment("c", 5, day.Add(3*time.Hour))
+		controversial.Controversiality = 1
+		return []*Comment{
+			newTestComment("a", 10, day.Add(time.Hour),
+				newTestComment("a1", 1, day.Add(4*time.Hour)),
+				newTestComment("a2", 7, day.Add(5*time.Hour)),
+			),
+			sticky,
+			newTestComment("b", 20, day.Add(2*time.Hour)),
+			controversial,
+		}
+	}
+
+	comments := newComments()
+	SortComments(comments, "top")
+	require.Equal(t, []string{"sticky", "b", "a", "c"}, commentIDs(comments))
+	require.Equal(t, []string{"a2", "a1"}, commentIDs(comments[2].Replies.Comments))
+
+	comments = newComments()
+	SortComments(comments, "new")
+	require.Equal(t, []string{"sticky", "c", "b", "a"}, commentIDs(comments))
+	require.Equal(t, []string{"a2", "a1"}, commentIDs(comments[3].Replies.Comments))
+
+	comments = newComments()
+	SortComments(comments, "old")
+	require.Equal(t, []string{"sticky", "a", "b", "c"}, commentIDs(comments))
+	require.Equal(t, []string{"a1", "a2"}, commentIDs(comments[1].Replies.Comments))
+
+	comments = newComments()
+	SortComments(comments, "controversial")
+	require.Equal(t, []string{"sticky", "c", "b", "a"}, commentIDs(comments))
+
+	comments = newComments()
+	SortComments(comments, "random")
+	require.Equal(t, []string{"sticky", "a", "b", "c"}, commentIDs(comments))
+
+	comments = newComments()
+	SortComments(comments, "top", SortStickiedFirst(true))
+	require.Equal(t, []string{"sticky", "b", "a", "c"}, commentIDs(comments))
+
+	comments = newComments()
+	SortComments(comments, "top", SortStickiedFirst(false))
+	require.Equal(t, []string{"b", "a", "c", "sticky"}, commentIDs(comments))
+
+	comments = newComments()
+	SortComments(comments, "random", SortStickiedFirst(false))
+	require.Equal(t, []string{"a", "sticky", "b", "c"}, commentIDs(comments))
+}
+
+func TestSortComments_Best(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	newComments := func() []*Comment {
+		controversial := newTestComment("a", 10, day)
+		controversial.Controversiality = 1
+		hidden := newTestComment("e", 0, day)
+		hidden.ScoreHidden = true
+		return []*Comment{
+			controversial,
+			newTestComment("b", 8, day),
+			newTestComment("c", -2, day),
+			newTestComment("d", 3, day),
+			hidden,
+		}
+	}
+
+	comments := newComments()
+	SortComments(comments, "top")
+	require.Equal(t, []string{"a", "b", "d", "e", "c"}, commentIDs(comments))
+
+	// the controversial comment has the highest score, but is likely to have
+	// a much lower upvote ratio than the others with a positive score
+	comments = newComments()
+	SortComments(comments, "best")
+	require.Equal(t, []string{"b", "d", "a", "e", "c"}, commentIDs(comments))
+
+	comments = newComments()
+	SortComments(comments, "confidence")
+	require.Equal(t, []string{"b", "d", "a", "e", "c"}, commentIDs(comments))
+}
+
+func TestDecodePostAndCommentsSorted(t *testing.T) {
+	data := []byte(`[
+		{"kind": "Listing", "data": {"children": [
+			{"kind": "t3", "data": {"id": "abc", "name": "t3_abc", "suggested_sort": "top"}}
+		]}},
+		{"kind": "Listing", "data": {"children": [
+			{"kind": "t1", "data": {"id": "c1", "name": "t1_c1", "score": 1, "replies": ""}},
+			{"kind": "t1", "data": {"id": "c2", "name": "t1_c2", "score": 3, "replies": ""}},
+			{"kind": "t1", "data": {"id": "c3", "name": "t1_c3", "score": 2, "replies": ""}}
+		]}}
+	]`)
+
+	pc, err := DecodePostAndCommentsSorted(data)
+	require.NoError(t, err)
+	require.Equal(t, "top", pc.Post.SuggestedSort)
+	require.Equal(t, []string{"c2", "c3", "c1"}, commentIDs(pc.Comments))
+
+	_, err = DecodePostAndCommentsSorted([]byte(`{}`))
+	require.Error(t, err)
+}
+
+func TestThreadToMarkdown(t *testing.T) {
+	require.Empty(t, ThreadToMarkdown(nil))
+
+	pc := &PostAndComments{
+		Post: &Post{
+			Title:                 "Test",
+			Body:                  "Hello",
+			Author:                "op",
+			SubredditNamePrefixed: "r/test",
+		},
+		Comments: []*Comment{
+			{
+				Author: "someone",
+				Body:   "Hi",
+				Score:  2,
+				Replies: Replies{
+					Comments: []*Comment{
+						{Author: "op", Body: "Hey\nthere", Score: 1, IsSubmitter: true},
+					},
+				},
+			},
+		},
+	}
+
+	want := `# Test
+
+*posted by u/op in r/test*
+
+Hello
+
+**u/someone** · 2 points
+
+Hi
+>
+> **u/op** (OP) · 1 points
+>
+> Hey
+> there
+`
+	require.Equal(t, want, ThreadToMarkdown(pc))
+}
+
+func TestForestEqual(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	newForest := func(score int) []*Comment {
+		return []*Comment{
+			newTestComment("a", score, day,
+				newTestComment("a1", score, day),
+				newTestComment("a2", score, day,
+					newTestComment("a21", score, day),
+				),
+			),
+			newTestComment("b", score, day),
+		}
+	}
+
+	require.True(t, ForestEqual(nil, nil))
+	require.True(t, ForestEqual(newForest(1), newForest(1)))
+
+	// fluctuations of scores and timestamps don't matter
+	other := newForest(5)
+	other[0].Created = &Timestamp{day.Add(time.Hour)}
+	require.True(t, ForestEqual(newForest(1), other))
+
+	// a missing reply deep in the tree does
+	other = newForest(1)
+	other[0].Replies.Comments[1].Replies.Comments = nil
+	require.False(t, ForestEqual(newForest(1), other))
+
+	// so does the order of the comments
+	other = newForest(1)
+	other[0], other[1] = other[1], other[0]
+	require.False(t, ForestEqual(newForest(1), other))
+
+	other = newForest(1)[:1]
+	require.False(t, ForestEqual(newForest(1), other))
+}
+
+func TestComment_WalkBFS(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	root := newTestComment("root", 1, day,
+		newTestComment("a", 1, day,
+			newTestComment("a1", 1, day,
+				newTestComment("a11", 1, day),
+			),
+			newTestComment("a2", 1, day),
+		),
+		newTestComment("b", 1, day,
+			newTestComment("b1", 1, day),
+		),
+	)
+
+	var ids []string
+	var levels []int
+	root.WalkBFS(func(c *Comment, level int) bool {
+		ids = append(ids, c.ID)
+		levels = append(levels, level)
+		return true
+	})
+	require.Equal(t, []string{"root", "a", "b", "a1", "a2", "b1", "a11"}, ids)
+	require.Equal(t, []int{0, 1, 1, 2, 2, 2, 3}, levels)
+
+	ids = nil
+	root.WalkBFS(func(c *Comment, level int) bool {
+		if level > 1 {
+			return false
+		}
+		ids = append(ids, c.ID)
+		return true
+	})
+	require.Equal(t, []string{"root", "a", "b"}, ids)
+}
+
+func TestComment_Walk(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	root := newTestComment("root", 1, day,
+		newTestComment("a", 1, day,
+			newTestComment("a1", 1, day,
+				newTestComment("a11", 1, day),
+			),
+			newTestComment("a2", 1, day),
+		),
+		newTestComment("b", 1, day,
+			newTestComment("b1", 1, day),
+		),
+	)
+
+	var ids []string
+	root.Walk(func(c *Comment) bool {
+		ids = append(ids, c.ID)
+		return true
+	})
+	require.Equal(t, []string{"root", "a", "a1", "a11", "a2", "b", "b1"}, ids)
+
+	ids = nil
+	root.Walk(func(c *Comment) bool {
+		if c.ID == "a2" {
+			return false
+		}
+		ids = append(ids, c.ID)
+		return true
+	})
+	require.Equal(t, []string{"root", "a", "a1", "a11"}, ids)
+}
+
+func TestPostAndComments_Flatten(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	pc := &PostAndComments{
+		Comments: []*Comment{
+			newTestComment("a", 1, day,
+				newTestComment("a1", 1, day,
+					newTestComment("a11", 1, day),
+				),
+				newTestComment("a2", 1, day),
+			),
+			newTestComment("b", 1, day),
+		},
+	}
+
+	comments := pc.Flatten()
+	require.Equal(t, []string{"a", "a1", "a11", "a2", "b"}, commentIDs(comments))
+
+	flat := pc.FlattenWithDepth()
+	require.Len(t, flat, len(comments))
+	depths := make([]int, len(flat))
+	for i, c := range flat {
+		require.Equal(t, comments[i], c.Comment)
+		depths[i] = c.Depth
+	}
+	require.Equal(t, []int{0, 1, 2, 1, 0}, depths)
+
+	// the tree is left untouched
+	require.Equal(t, []string{"a", "b"}, commentIDs(pc.Comments))
+	require.Equal(t, []string{"a1", "a2"}, commentIDs(pc.Comments[0].Replies.Comments))
+
+	require.Empty(t, new(PostAndComments).FlattenWithDepth())
+}
+
+func TestPostAndComments_FindComment(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	pc := &PostAndComments{
+		Comments: []*Comment{
+			newTestComment("a", 1, day,
+				newTestComment("a1", 1, day,
+					newTestComment("a11", 1, day),
+				),
+			),
+			newTestComment("b", 1, day,
+				newTestComment("b1", 1, day),
+			),
+		},
+	}
+
+	require.Equal(t, pc.Comments[1], pc.FindComment("t1_b"))
+	require.Equal(t, pc.Comments[1].Replies.Comments[0], pc.FindComment("t1_b1"))
+	require.Equal(t, "a11", pc.FindComment("t1_a11").ID)
+	require.Nil(t, pc.FindComment("t1_c"))
+
+	a := pc.Comments[0]
+	require.Equal(t, "a11", a.FindReply("t1_a11").ID)
+	require.Nil(t, a.FindReply("t1_b1"))
+	// the comment itself isn't one of its replies
+	require.Nil(t, a.FindReply("t1_a"))
+}
+
+func TestPostAndComments_IsReplyToAuthor(t *testing.T) {
+	newComment := func(id, parentID, author string, replies ...*Comment) *Comment {
+		return &Comment{
+			FullID:   "t1_" + id,
+			ParentID: parentID,
+			Author:   author,
+			Replies:  Replies{Comments: replies},
+		}
+	}
+
+	// alice replies to herself twice, then bob replies to her
+	bob := newComment("d", "t1_c", "bob")
+	self2 := newComment("c", "t1_b", "alice", bob)
+	self1 := newComment("b", "t1_a", "alice", self2)
+	top := newComment("a", "t3_post", "alice", self1)
+	op := newComment("e", "t3_post", "op")
+
+	pc := &PostAndComments{
+		Post:     &Post{FullID: "t3_post", Author: "op"},
+		Comments: []*Comment{top, op},
+	}
+
+	require.False(t, pc.IsReplyToAuthor(top))
+	require.True(t, pc.IsReplyToAuthor(self1))
+	require.True(t, pc.IsReplyToAuthor(self2))
+	require.False(t, pc.IsReplyToAuthor(bob))
+	// the author of the post replying to it
+	require.True(t, pc.IsReplyToAuthor(op))
+
+	// the parent isn't in the tree
+	require.False(t, pc.IsReplyToAuthor(newComment("f", "t1_missing", "alice")))
+}
+
+func TestComment_Walk_DeepThread(t *testing.T) {
+	const depth = 5000
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+
+	// a linear chain of replies, each comment replying to the previous one
+	root := newTestComment("0", 1, day)
+	last := root
+	for i := 1; i < depth; i++ {
+		reply := newTestComment(strconv.Itoa(i), 1, day)
+		last.Replies.Comments = []*Comment{reply}
+		last = reply
+	}
+
+	count := 0
+	root.Walk(func(c *Comment) bool {
+		require.Equal(t, strconv.Itoa(count), c.ID)
+		count++
+		return true
+	})
+	require.Equal(t, depth, count)
+
+	comments := (&PostAndComments{Comments: []*Comment{root}}).Flatten()
+	require.Len(t, comments, depth)
+	require.Equal(t, last, comments[depth-1])
+
+	require.Empty(t, new(PostAndComments).Flatten())
+}
+
+func TestPostAndComments_TreeHash(t *testing.T) {
+	blob, err := readFileContents("../testdata/post/post.json")
+	require.NoError(t, err)
+
+	decode := func() *PostAndComments {
+		pc := new(PostAndComments)
+		err := json.Unmarshal([]byte(blob), pc)
+		require.NoError(t, err)
+		return pc
+	}
+
+	pc := decode()
+	hash := pc.TreeHash()
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, decode().TreeHash())
+
+	// fields other than the structure and bodies don't affect the hash
+	pc.Comments[0].Score += 10
+	require.Equal(t, hash, pc.TreeHash())
+
+	pc.Comments[0].Replies.Comments[0].Body = "Hello (edited)"
+	require.NotEqual(t, hash, pc.TreeHash())
+
+	// moving a reply up to the top level changes the structure
+	pc = decode()
+	reply := pc.Comments[0].Replies.Comments[0]
+	pc.Comments[0].Replies.Comments = nil
+	pc.Comments = append(pc.Comments, reply)
+	require.NotEqual(t, hash, pc.TreeHash())
+
+	require.Equal(t, (&PostAndComments{}).TreeHash(), (&PostAndComments{}).TreeHash())
+}
+
+func TestPostAndComments_AuthorCommentCounts(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	withAuthor := func(c *Comment, author string) *Comment {
+		c.Author = author
+		return c
+	}
+
+	pc := &PostAndComments{
+		Comments: []*Comment{
+			withAuthor(newTestComment("a", 1, day,
+				withAuthor(newTestComment("a1", 1, day,
+					withAuthor(newTestComment("a11", 1, day), "alice"),
+					withAuthor(newTestComment("a12", 1, day), "[deleted]"),
+				), "bob"),
+			), "alice"),
+			withAuthor(newTestComment("b", 1, day,
+				withAuthor(newTestComment("b1", 1, day), "alice"),
+			), "bob"),
+			withAuthor(newTestComment("c", 1, day), "[deleted]"),
+		},
+	}
+
+	require.Equal(t, map[string]int{
+		"alice": 3,
+		"bob":   2,
+	}, pc.AuthorCommentCounts())
+
+	require.Empty(t, new(PostAndComments).AuthorCommentCounts())
+}
+
+func TestPostAndComments_ScorePercentile(t *testing.T) {
+	day := time.Date(2020, 7, 18, 0, 0, 0, 0, time.UTC)
+	pc := &PostAndComments{
+		Comments: []*Comment{
+			newTestComment("a", 50, day,
+				newTestComment("a1", 10, day,
+					newTestComment("a11", -5, day),
+				),
+				newTestComment("a2", 10, day),
+			),
+			newTestComment("b", 1, day),
+		},
+	}
+
+	a := pc.FindComment("t1_a")
+	a1 := pc.FindComment("t1_a1")
+	a11 := pc.FindComment("t1_a11")
+	b := pc.FindComment("t1_b")
+
+	require.Equal(t, 100.0, pc.ScorePercentile(a))
+	require.Equal(t, 0.0, pc.ScorePercentile(a11))
+	require.Equal(t, 25.0, pc.ScorePercentile(b))
+	// a2 has the same score, so it counts for half
+	require.Equal(t, 62.5, pc.ScorePercentile(a1))
+
+	// a comment outside of the thread is compared against all of its comments
+	require.Equal(t, 30.0, pc.ScorePercentile(newTestComment("c", 1, day)))
+
+	single := &PostAndComments{Comments: []*Comment{newTestComment("a", 0, day)}}
+	require.Equal(t, 100.0, single.ScorePercentile(single.Comments[0]))
+}
+
+func TestEncodeThread(t *testing.T) {
+	blob, err := readFileContents("../testdata/post/post.json")
+	require.NoError(t, err)
+
+	pc := new(PostAndComments)
+	err = json.Unmarshal([]byte(blob), pc)
+	require.NoError(t, err)
+	pc.More = &More{ID: "more1", FullID: "t1_more1", ParentID: "t3_testpost", Count: 1, Children: []string{"more1"}}
+
+	buf := new(bytes.Buffer)
+	err = EncodeThread(buf, pc)
+	require.NoError(t, err)
+
+	expected, err := json.Marshal([]thing{
+		{Kind: kindListing, Data: &listing{things: things{Posts: []*Post{pc.Post}}}},
+		{Kind: kindListing, Data: &listing{things: things{Comments: pc.Comments, Mores: []*More{pc.More}}}},
+	})
+	require.NoError(t, err)
+
+	actual := new(bytes.Buffer)
+	err = json.Compact(actual, buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, string(expected), actual.String())
+
+	decoded := new(PostAndComments)
+	err = json.Unmarshal(buf.Bytes(), decoded)
+	require.NoError(t, err)
+	require.Equal(t, pc, decoded)
+
+	buf.Reset()
+	require.EqualError(t, EncodeThread(buf, nil), "*PostAndComments: cannot be nil")
+	require.Zero(t, buf.Len())
+}
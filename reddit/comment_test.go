@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -21,6 +22,11 @@ var expectedCommentSubmitOrEdit = &Comment{
 	AuthorID:        "t2_user1",
 	AuthorFlairText: "Flair",
 	AuthorFlairID:   "024b2b66-05ca-11e1-96f4-12313d096aae",
+	AuthorFlairRichText: []FlairSegment{
+		{Type: "text", Text: "Beginner - Strength"},
+	},
+
+	Awardings: []*Award{},
 
 	SubredditName:         "subreddit",
 	SubredditNamePrefixed: "r/subreddit",
@@ -353,3 +359,22 @@ func TestCommentService_Report(t *testing.T) {
 	_, err := client.Comment.Report(ctx, "t1_test", "test reason")
 	require.NoError(t, err)
 }
+
+func TestCommentService_Delete_Forbidden(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/del", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Forbidden", "error": 403}`)
+	})
+
+	_, err := client.Comment.Delete(ctx, "t1_test")
+	require.IsType(t, &ErrorResponse{}, err)
+	require.True(t, err.(*ErrorResponse).Forbidden())
+
+	var permissionErr *PermissionError
+	require.True(t, errors.As(err, &permissionErr))
+	require.Equal(t, "Forbidden", permissionErr.Message)
+	require.Equal(t, http.StatusForbidden, permissionErr.Response.StatusCode)
+}
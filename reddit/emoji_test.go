@@ -2,6 +2,7 @@ package reddit
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -62,6 +63,32 @@ func TestEmojiService_Get(t *testing.T) {
 	require.Equal(t, expectedSubredditEmojis, subredditEmojis)
 }
 
+func TestEmojis_UnmarshalJSON(t *testing.T) {
+	var e emojis
+	err := json.Unmarshal([]byte(`{
+		"cake": {
+			"url": "https://emoji.redditmedia.com/46kel8lf1guz_t5_3nqvj/cake",
+			"user_flair_allowed": true,
+			"post_flair_allowed": true,
+			"mod_flair_only": false,
+			"created_by": "t2_6zfp6ii"
+		},
+		"cat_blep": {
+			"url": "https://emoji.redditmedia.com/p9sxc1zh1guz_t5_3nqvj/cat_blep",
+			"user_flair_allowed": true,
+			"post_flair_allowed": true,
+			"mod_flair_only": false,
+			"created_by": "t2_6zfp6ii"
+		}
+	}`), &e)
+	require.NoError(t, err)
+	require.Len(t, e, 2)
+	require.ElementsMatch(t, expectedDefaultEmojis, []*Emoji(e))
+
+	err = json.Unmarshal([]byte(`{"cake": "invalid"}`), &e)
+	require.Error(t, err)
+}
+
 func TestEmojiService_Delete(t *testing.T) {
 	client, mux := setup(t)
 
@@ -57,6 +57,36 @@ func (r *JSONErrorResponse) Error() string {
 	)
 }
 
+// Unwrap returns the first error reported by Reddit, so that errors.As can be used to
+// get it as an *APIError, e.g. to check its label. Use the JSON field to get all of them.
+func (r *JSONErrorResponse) Unwrap() error {
+	if len(r.JSON.Errors) == 0 {
+		return nil
+	}
+	return &r.JSON.Errors[0]
+}
+
+// Labels of errors Reddit can report in a JSONErrorResponse.
+const (
+	// The client is doing something, e.g. submitting posts, too often.
+	ErrorLabelRateLimit = "RATELIMIT"
+	// The user isn't allowed to submit to the subreddit.
+	ErrorLabelSubredditNotAllowed = "SUBREDDIT_NOTALLOWED"
+	// The link was already submitted to the subreddit.
+	// It can be submitted again by setting Resubmit in SubmitLinkRequest.
+	ErrorLabelAlreadySubmitted = "ALREADY_SUB"
+)
+
+// HasLabel reports whether Reddit reported an error with the label, e.g. RATELIMIT.
+func (r *JSONErrorResponse) HasLabel(label string) bool {
+	for _, err := range r.JSON.Errors {
+		if err.Label == label {
+			return true
+		}
+	}
+	return false
+}
+
 // An ErrorResponse reports the error caused by an API request
 type ErrorResponse struct {
 	// HTTP response that caused this error
@@ -73,6 +103,38 @@ func (r *ErrorResponse) Error() string {
 	)
 }
 
+// Forbidden reports whether the error was caused by the client not being allowed to
+// do what it requested, e.g. delete or edit a comment or post of another user.
+func (r *ErrorResponse) Forbidden() bool {
+	return r.Response != nil && r.Response.StatusCode == http.StatusForbidden
+}
+
+// Unwrap returns a *PermissionError if the request was forbidden, so that it
+// can be matched with errors.As, and nil otherwise.
+func (r *ErrorResponse) Unwrap() error {
+	if !r.Forbidden() {
+		return nil
+	}
+	return &PermissionError{Response: r.Response, Message: r.Message}
+}
+
+// PermissionError occurs when Reddit refuses a request because the client isn't allowed to do it,
+// e.g. delete or edit a comment or post of another user. It is wrapped by the *ErrorResponse
+// returned for the request.
+type PermissionError struct {
+	// HTTP response that caused this error
+	Response *http.Response
+	// Error message
+	Message string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf(
+		"%s %s: %d %s",
+		e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, e.Message,
+	)
+}
+
 // RateLimitError occurs when the client is sending too many requests to Reddit in a given time frame.
 type RateLimitError struct {
 	// Rate specifies the last known rate limit for the client
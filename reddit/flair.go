@@ -34,6 +34,16 @@ type Flair struct {
 	ModOnly  bool `json:"mod_only"`
 }
 
+// FlairSegment is a part of a rich text flair, which is either some text or an emoji.
+type FlairSegment struct {
+	// Either text or emoji.
+	Type string `json:"e,omitempty"`
+	Text string `json:"t,omitempty"`
+	// The name of the emoji, e.g. :upvote:, and the URL of its image.
+	EmojiName string `json:"a,omitempty"`
+	EmojiURL  string `json:"u,omitempty"`
+}
+
 // FlairSummary is a condensed version of Flair.
 type FlairSummary struct {
 	User     string `json:"user,omitempty"`
@@ -0,0 +1,24 @@
+// +build gofuzz
+
+package reddit
+
+import "bytes"
+
+// Fuzz is the entrypoint for go-fuzz, checking that RoundTrip is stable on its own output.
+// The seed corpus is in testdata/fuzz/roundtrip/corpus.
+func Fuzz(data []byte) int {
+	out, err := RoundTrip(data)
+	if err != nil {
+		return 0
+	}
+
+	again, err := RoundTrip(out)
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(out, again) {
+		panic("round trip is not stable")
+	}
+
+	return 1
+}
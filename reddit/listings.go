@@ -14,6 +14,24 @@ type ListingsService struct {
 	client *Client
 }
 
+// Reddit returns at most 100 things per request to api/info.
+const maxInfoIDs = 100
+
+// BuildInfoQuery joins full IDs with commas, to be used as the id parameter of api/info.
+// Since the endpoint only accepts up to 100 IDs per request, it returns one value per batch of 100.
+func BuildInfoQuery(fullnames []string) []string {
+	var queries []string
+	for len(fullnames) > 0 {
+		n := len(fullnames)
+		if n > maxInfoIDs {
+			n = maxInfoIDs
+		}
+		queries = append(queries, strings.Join(fullnames[:n], ","))
+		fullnames = fullnames[n:]
+	}
+	return queries
+}
+
 // Get posts, comments, and subreddits from their full IDs.
 func (s *ListingsService) Get(ctx context.Context, ids ...string) ([]*Post, []*Comment, []*Subreddit, *Response, error) {
 	path := "api/info"
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,11 @@ var expectedListingPosts = []*Post{
 		Author:   "v_95",
 		AuthorID: "t2_164ab8",
 
+		Thumbnail: "self",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
 		IsSelfPost: true,
 	},
 }
@@ -50,9 +56,12 @@ var expectedListingComments = []*Comment{
 		ParentID:  "t3_i2gvg4",
 		Permalink: "/r/test/comments/i2gvg4/this_is_a_title/g05v931/",
 
-		Body:     "Test comment",
-		Author:   "v_95",
-		AuthorID: "t2_164ab8",
+		Body:                "Test comment",
+		Author:              "v_95",
+		AuthorID:            "t2_164ab8",
+		AuthorFlairRichText: []FlairSegment{},
+
+		Awardings: []*Award{},
 
 		SubredditName:         "test",
 		SubredditNamePrefixed: "r/test",
@@ -79,6 +88,8 @@ var expectedListingSubreddits = []*Subreddit{
 		Name:         "test",
 		NamePrefixed: "r/test",
 		Title:        "Testing",
+		SidebarMD:    "This is a place to test things.",
+		SidebarHTML:  "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;This is a place to test things.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
 		Type:         "public",
 
 		Subscribers: 8202,
@@ -112,6 +123,11 @@ var expectedListingPosts2 = []*Post{
 		Author:   "v_95",
 		AuthorID: "t2_164ab8",
 
+		Thumbnail: "self",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
 		IsSelfPost: true,
 	},
 	{
@@ -137,6 +153,11 @@ var expectedListingPosts2 = []*Post{
 
 		Author:   "v_95",
 		AuthorID: "t2_164ab8",
+
+		Thumbnail: "default",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
 	},
 }
 
@@ -181,3 +202,21 @@ func TestListingsService_GetPosts(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedListingPosts2, posts)
 }
+
+func TestBuildInfoQuery(t *testing.T) {
+	require.Nil(t, BuildInfoQuery(nil))
+
+	queries := BuildInfoQuery([]string{"t3_abc", "t1_def", "t5_ghi"})
+	require.Equal(t, []string{"t3_abc,t1_def,t5_ghi"}, queries)
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("t3_%d", i)
+	}
+
+	queries = BuildInfoQuery(ids)
+	require.Len(t, queries, 3)
+	require.Equal(t, strings.Join(ids[:100], ","), queries[0])
+	require.Equal(t, strings.Join(ids[100:200], ","), queries[1])
+	require.Equal(t, strings.Join(ids[200:], ","), queries[2])
+}
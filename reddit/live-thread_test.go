@@ -126,6 +126,14 @@ var expectedLiveThreadDiscussions = []*Post{
 
 		Author:   "TestUser",
 		AuthorID: "t2_test1",
+
+		Thumbnail: "default",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
+		Media:       &Media{Type: "liveupdate"},
+		SecureMedia: &Media{Type: "liveupdate"},
 	},
 	{
 		ID:      "test2",
@@ -142,6 +150,8 @@ var expectedLiveThreadDiscussions = []*Post{
 		UpvoteRatio:      0.97,
 		NumberOfComments: 34,
 
+		SuggestedSort: "new",
+
 		SubredditName:         "live",
 		SubredditNamePrefixed: "r/live",
 		SubredditID:           "t5_32o7w",
@@ -149,6 +159,16 @@ var expectedLiveThreadDiscussions = []*Post{
 
 		Author:   "TestUser",
 		AuthorID: "t2_test1",
+
+		LinkFlairID: "9b12fc60-ff01-11e3-b179-12313b0a9e38",
+
+		Thumbnail: "https://b.thumbs.redditmedia.com/rZKNaYfha47BqSqVTn2S7WGm5-ydloMOqz3Oqli87aU.jpg",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
+		Media:       &Media{Type: "liveupdate"},
+		SecureMedia: &Media{Type: "liveupdate"},
 	},
 }
 
@@ -0,0 +1,277 @@
+package reddit
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Media holds information about media embedded in a post.
+type Media struct {
+	// The domain of the embedded media, e.g. youtube.com.
+	// This is not set for videos hosted on Reddit.
+	Type        string       `json:"type,omitempty"`
+	RedditVideo *RedditVideo `json:"reddit_video,omitempty"`
+}
+
+// RedditVideo is a video hosted on Reddit.
+type RedditVideo struct {
+	// Direct link to the video, without audio.
+	FallbackURL string `json:"fallback_url,omitempty"`
+	HLSURL      string `json:"hls_url,omitempty"`
+	DASHURL     string `json:"dash_url,omitempty"`
+
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Length of the video, in seconds.
+	Duration int `json:"duration"`
+
+	IsGIF bool `json:"is_gif"`
+}
+
+// Preview holds preview images of a post, generated by Reddit from the media it links to.
+type Preview struct {
+	Images  []*PreviewImage `json:"images,omitempty"`
+	Enabled bool            `json:"enabled"`
+}
+
+// PreviewImage is a preview image in various resolutions.
+type PreviewImage struct {
+	ID     string         `json:"id,omitempty"`
+	Source *PreviewSource `json:"source,omitempty"`
+	// Downscaled versions of the image, ordered from smallest to largest.
+	Resolutions []*PreviewSource `json:"resolutions,omitempty"`
+}
+
+// PreviewSource is a single resolution of a preview image.
+type PreviewSource struct {
+	URL    string `json:"url,omitempty"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// GalleryData holds the items of a gallery post.
+type GalleryData struct {
+	Items []*GalleryItem `json:"items,omitempty"`
+}
+
+// GalleryItem is an item of a gallery post.
+type GalleryItem struct {
+	ID          int    `json:"id"`
+	MediaID     string `json:"media_id,omitempty"`
+	Caption     string `json:"caption,omitempty"`
+	OutboundURL string `json:"outbound_url,omitempty"`
+
+	// URL of the media item, resolved from the post's media metadata.
+	// This is only set on the items returned by Post.GalleryItems.
+	URL string `json:"-"`
+}
+
+// MediaMetadata holds information about a media item attached to a post, such as an image in a gallery.
+type MediaMetadata struct {
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+	// The type of media, e.g. Image, AnimatedImage.
+	Type     string `json:"e,omitempty"`
+	MIMEType string `json:"m,omitempty"`
+
+	// The original, full-size media.
+	Source *MediaSource `json:"s,omitempty"`
+	// Downscaled versions of the media, ordered from smallest to largest.
+	Previews []*MediaSource `json:"p,omitempty"`
+}
+
+// MediaSource is a single resolution of a media item.
+// Static images have a URL, while animated ones have a GIF and/or MP4 instead.
+type MediaSource struct {
+	URL    string `json:"u,omitempty"`
+	GIF    string `json:"gif,omitempty"`
+	MP4    string `json:"mp4,omitempty"`
+	Width  int    `json:"x"`
+	Height int    `json:"y"`
+}
+
+func (s *MediaSource) url() string {
+	if s == nil {
+		return ""
+	}
+
+	u := s.URL
+	if u == "" {
+		u = s.GIF
+	}
+	if u == "" {
+		u = s.MP4
+	}
+
+	// Reddit HTML-escapes the URLs in media metadata, e.g. & becomes &amp;
+	return html.UnescapeString(u)
+}
+
+// URL returns the URL of the media item in its best available quality.
+// It uses the source if present, otherwise the largest preview.
+func (m *MediaMetadata) URL() (string, bool) {
+	if m == nil {
+		return "", false
+	}
+
+	if u := m.Source.url(); u != "" {
+		return u, true
+	}
+
+	for i := len(m.Previews) - 1; i >= 0; i-- {
+		if u := m.Previews[i].url(); u != "" {
+			return u, true
+		}
+	}
+
+	return "", false
+}
+
+// ResolveMediaMetadata resolves the URL of a media item from the raw media_metadata
+// object of a post, which maps media IDs to their metadata.
+func ResolveMediaMetadata(raw json.RawMessage, mediaID string) (url string, ok bool) {
+	metadata := make(map[string]*MediaMetadata)
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return "", false
+	}
+	return metadata[mediaID].URL()
+}
+
+// GalleryItems returns the items of a gallery post, in the order they appear in the gallery,
+// with their URL resolved from the post's media metadata.
+// The gallery data of the post is not modified.
+func (p *Post) GalleryItems() []*GalleryItem {
+	if p.GalleryData == nil {
+		return nil
+	}
+
+	items := make([]*GalleryItem, 0, len(p.GalleryData.Items))
+	for _, item := range p.GalleryData.Items {
+		if item == nil {
+			continue
+		}
+
+		resolved := *item
+		resolved.URL, _ = p.MediaMetadata[item.MediaID].URL()
+		items = append(items, &resolved)
+	}
+
+	return items
+}
+
+// GenerateThumbnailURL returns the URL of the smallest preview image of the post that is
+// at least width pixels wide, or of the largest one if none is wide enough.
+// If the post has no preview, it falls back to its thumbnail, if the thumbnail is a URL.
+// Reddit sets the thumbnail to a keyword like self or default when there is no actual image.
+func (p *Post) GenerateThumbnailURL(width int) string {
+	if p.Preview != nil && len(p.Preview.Images) > 0 && p.Preview.Images[0] != nil {
+		image := p.Preview.Images[0]
+
+		candidates := append([]*PreviewSource{}, image.Resolutions...)
+		if image.Source != nil {
+			candidates = append(candidates, image.Source)
+		}
+
+		var best *PreviewSource
+		for _, candidate := range candidates {
+			if candidate == nil || candidate.URL == "" {
+				continue
+			}
+			switch {
+			case best == nil:
+				best = candidate
+			case best.Width < width:
+				// anything wider is closer to the requested width
+				if candidate.Width > best.Width {
+					best = candidate
+				}
+			case candidate.Width >= width && candidate.Width < best.Width:
+				best = candidate
+			}
+		}
+
+		if best != nil {
+			return html.UnescapeString(best.URL)
+		}
+	}
+
+	if strings.HasPrefix(p.Thumbnail, "http://") || strings.HasPrefix(p.Thumbnail, "https://") {
+		return p.Thumbnail
+	}
+
+	return ""
+}
+
+// BestVideo returns the video hosted on Reddit of the post, preferring the one
+// served over HTTPS, or nil if the post doesn't have one.
+func (p *Post) BestVideo() *RedditVideo {
+	if p.SecureMedia != nil && p.SecureMedia.RedditVideo != nil {
+		return p.SecureMedia.RedditVideo
+	}
+	if p.Media != nil && p.Media.RedditVideo != nil {
+		return p.Media.RedditVideo
+	}
+	return nil
+}
+
+// AspectRatio returns the ratio of the width to the height of the post's media,
+// taken from its video hosted on Reddit, or otherwise from its preview image.
+// It returns false if the post has neither, e.g. a text post.
+func (p *Post) AspectRatio() (float64, bool) {
+	if v := p.BestVideo(); v != nil && v.Width > 0 && v.Height > 0 {
+		return float64(v.Width) / float64(v.Height), true
+	}
+
+	if p.Preview != nil && len(p.Preview.Images) > 0 && p.Preview.Images[0] != nil {
+		if source := p.Preview.Images[0].Source; source != nil && source.Width > 0 && source.Height > 0 {
+			return float64(source.Width) / float64(source.Height), true
+		}
+	}
+
+	return 0, false
+}
+
+var imageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
+// isImageURL reports whether u points directly to an image file.
+func isImageURL(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return imageExtensions[strings.ToLower(path.Ext(parsed.Path))]
+}
+
+// DownloadURLs returns the URLs of the media that can be downloaded from the post:
+// the images of a gallery, the video of a post with a video hosted on Reddit, or
+// the URL of a link post pointing directly to an image.
+// It returns nil if the post has nothing to download, e.g. a text post.
+func (p *Post) DownloadURLs() []string {
+	var urls []string
+
+	switch {
+	case p.GalleryData != nil:
+		for _, item := range p.GalleryItems() {
+			if item.URL != "" {
+				urls = append(urls, item.URL)
+			}
+		}
+	case p.IsVideo && p.BestVideo() != nil:
+		if u := p.BestVideo().FallbackURL; u != "" {
+			urls = append(urls, u)
+		}
+	case !p.IsSelfPost && isImageURL(p.URL):
+		urls = append(urls, p.URL)
+	}
+
+	return urls
+}
@@ -0,0 +1,214 @@
+package reddit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var rawMediaMetadata = json.RawMessage(`{
+	"img1": {
+		"status": "valid",
+		"e": "Image",
+		"m": "image/jpg",
+		"p": [
+			{"y": 108, "x": 108, "u": "https://preview.redd.it/img1.jpg?width=108&amp;format=pjpg"},
+			{"y": 216, "x": 216, "u": "https://preview.redd.it/img1.jpg?width=216&amp;format=pjpg"}
+		],
+		"s": {"y": 1080, "x": 1080, "u": "https://preview.redd.it/img1.jpg?width=1080&amp;format=pjpg"},
+		"id": "img1"
+	},
+	"img2": {
+		"status": "valid",
+		"e": "Image",
+		"m": "image/png",
+		"p": [
+			{"y": 108, "x": 108, "u": "https://preview.redd.it/img2.png?width=108"},
+			{"y": 216, "x": 216, "u": "https://preview.redd.it/img2.png?width=216"}
+		],
+		"id": "img2"
+	},
+	"gif1": {
+		"status": "valid",
+		"e": "AnimatedImage",
+		"m": "image/gif",
+		"s": {"y": 200, "x": 300, "gif": "https://i.redd.it/gif1.gif", "mp4": "https://preview.redd.it/gif1.gif?format=mp4"},
+		"id": "gif1"
+	}
+}`)
+
+func TestResolveMediaMetadata(t *testing.T) {
+	url, ok := ResolveMediaMetadata(rawMediaMetadata, "img1")
+	require.True(t, ok)
+	require.Equal(t, "https://preview.redd.it/img1.jpg?width=1080&format=pjpg", url)
+
+	url, ok = ResolveMediaMetadata(rawMediaMetadata, "img2")
+	require.True(t, ok)
+	require.Equal(t, "https://preview.redd.it/img2.png?width=216", url)
+
+	url, ok = ResolveMediaMetadata(rawMediaMetadata, "gif1")
+	require.True(t, ok)
+	require.Equal(t, "https://i.redd.it/gif1.gif", url)
+
+	url, ok = ResolveMediaMetadata(rawMediaMetadata, "missing")
+	require.False(t, ok)
+	require.Empty(t, url)
+
+	_, ok = ResolveMediaMetadata(json.RawMessage(`[]`), "img1")
+	require.False(t, ok)
+}
+
+func TestPost_DownloadURLs(t *testing.T) {
+	imagePost := &Post{URL: "https://i.redd.it/abc123.jpg"}
+	require.Equal(t, []string{"https://i.redd.it/abc123.jpg"}, imagePost.DownloadURLs())
+
+	galleryPost := new(Post)
+	err := json.Unmarshal([]byte(`{
+		"url": "https://www.reddit.com/gallery/abc123",
+		"is_gallery": true,
+		"gallery_data": {
+			"items": [
+				{"media_id": "gif1", "id": 3},
+				{"media_id": "img1", "id": 1, "caption": "first"},
+				{"media_id": "img2", "id": 2}
+			]
+		},
+		"media_metadata": `+string(rawMediaMetadata)+`
+	}`), galleryPost)
+	require.NoError(t, err)
+	require.True(t, galleryPost.IsGallery)
+	require.Equal(t, []string{
+		"https://i.redd.it/gif1.gif",
+		"https://preview.redd.it/img1.jpg?width=1080&format=pjpg",
+		"https://preview.redd.it/img2.png?width=216",
+	}, galleryPost.DownloadURLs())
+
+	videoPost := new(Post)
+	err = json.Unmarshal([]byte(`{
+		"url": "https://v.redd.it/abc123",
+		"is_video": true,
+		"media": {
+			"reddit_video": {
+				"fallback_url": "https://v.redd.it/abc123/DASH_720.mp4?source=fallback",
+				"height": 720,
+				"width": 1280,
+				"duration": 30,
+				"is_gif": false
+			}
+		}
+	}`), videoPost)
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://v.redd.it/abc123/DASH_720.mp4?source=fallback"}, videoPost.DownloadURLs())
+
+	linkPost := &Post{URL: "https://example.com/article"}
+	require.Nil(t, linkPost.DownloadURLs())
+
+	textPost := &Post{URL: "https://www.reddit.com/r/test/comments/abc123/test/", IsSelfPost: true}
+	require.Nil(t, textPost.DownloadURLs())
+}
+
+func TestPost_GalleryItems_Order(t *testing.T) {
+	blob, err := readFileContents("../testdata/post/gallery.json")
+	require.NoError(t, err)
+
+	post := new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+
+	// the order of the gallery data is kept, regardless of the order of the media metadata
+	for i := 0; i < 10; i++ {
+		require.Equal(t, []*GalleryItem{
+			{ID: 30, MediaID: "third", URL: "https://preview.redd.it/third.jpg?width=1280&format=pjpg"},
+			{ID: 10, MediaID: "first", Caption: "The first one", URL: "https://preview.redd.it/first.jpg?width=1920&format=pjpg"},
+			{ID: 20, MediaID: "second", OutboundURL: "https://example.com", URL: "https://preview.redd.it/second.png?width=800&format=png"},
+		}, post.GalleryItems())
+	}
+
+	// the post's gallery data itself is left untouched
+	require.Empty(t, post.GalleryData.Items[0].URL)
+}
+
+func TestPost_BestVideo(t *testing.T) {
+	post := new(Post)
+	err := json.Unmarshal([]byte(`{
+		"is_video": true,
+		"media": {
+			"reddit_video": {"fallback_url": "http://v.redd.it/abc123/DASH_720.mp4", "height": 720, "width": 1280}
+		},
+		"secure_media": {
+			"reddit_video": {"fallback_url": "https://v.redd.it/abc123/DASH_720.mp4", "height": 720, "width": 1280}
+		}
+	}`), post)
+	require.NoError(t, err)
+	require.Equal(t, "https://v.redd.it/abc123/DASH_720.mp4", post.BestVideo().FallbackURL)
+	require.Equal(t, []string{"https://v.redd.it/abc123/DASH_720.mp4"}, post.DownloadURLs())
+
+	post.SecureMedia = nil
+	require.Equal(t, "http://v.redd.it/abc123/DASH_720.mp4", post.BestVideo().FallbackURL)
+
+	require.Nil(t, (&Post{Media: &Media{Type: "youtube.com"}}).BestVideo())
+	require.Nil(t, new(Post).BestVideo())
+}
+
+func TestPost_GenerateThumbnailURL(t *testing.T) {
+	post := new(Post)
+	err := json.Unmarshal([]byte(`{
+		"thumbnail": "https://b.thumbs.redditmedia.com/abc123.jpg",
+		"preview": {
+			"images": [
+				{
+					"source": {"url": "https://preview.redd.it/abc123.jpg?auto=webp&amp;s=source", "width": 1200, "height": 800},
+					"resolutions": [
+						{"url": "https://preview.redd.it/abc123.jpg?width=108&amp;s=108", "width": 108, "height": 72},
+						{"url": "https://preview.redd.it/abc123.jpg?width=320&amp;s=320", "width": 320, "height": 213},
+						{"url": "https://preview.redd.it/abc123.jpg?width=640&amp;s=640", "width": 640, "height": 426}
+					],
+					"id": "abc123"
+				}
+			],
+			"enabled": true
+		}
+	}`), post)
+	require.NoError(t, err)
+
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?width=108&s=108", post.GenerateThumbnailURL(0))
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?width=108&s=108", post.GenerateThumbnailURL(108))
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?width=320&s=320", post.GenerateThumbnailURL(200))
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?width=640&s=640", post.GenerateThumbnailURL(640))
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?auto=webp&s=source", post.GenerateThumbnailURL(1000))
+	// nothing is wide enough, so the largest one is used
+	require.Equal(t, "https://preview.redd.it/abc123.jpg?auto=webp&s=source", post.GenerateThumbnailURL(4000))
+
+	post.Preview = nil
+	require.Equal(t, "https://b.thumbs.redditmedia.com/abc123.jpg", post.GenerateThumbnailURL(200))
+
+	post.Thumbnail = "self"
+	require.Empty(t, post.GenerateThumbnailURL(200))
+}
+
+func TestPost_AspectRatio(t *testing.T) {
+	imagePost := &Post{
+		Preview: &Preview{
+			Images: []*PreviewImage{
+				{Source: &PreviewSource{URL: "https://preview.redd.it/abc123.jpg", Width: 1200, Height: 800}},
+			},
+		},
+	}
+	ratio, ok := imagePost.AspectRatio()
+	require.True(t, ok)
+	require.Equal(t, 1.5, ratio)
+
+	videoPost := &Post{
+		IsVideo: true,
+		Media:   &Media{RedditVideo: &RedditVideo{Width: 1280, Height: 720}},
+		// the preview of a video is its thumbnail, which may be cropped
+		Preview: imagePost.Preview,
+	}
+	ratio, ok = videoPost.AspectRatio()
+	require.True(t, ok)
+	require.InDelta(t, 16.0/9.0, ratio, 1e-9)
+
+	_, ok = (&Post{IsSelfPost: true}).AspectRatio()
+	require.False(t, ok)
+}
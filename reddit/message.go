@@ -27,12 +27,51 @@ type Message struct {
 
 	Subject  string `json:"subject"`
 	Text     string `json:"body"`
+	TextHTML string `json:"body_html"`
 	ParentID string `json:"parent_id"`
+	// For comment replies and mentions, the permalink of the comment with some context.
+	Context string `json:"context"`
 
 	Author string `json:"author"`
 	To     string `json:"dest"`
 
 	IsComment bool `json:"was_comment"`
+	// Whether the message is unread.
+	New bool `json:"new"`
+
+	// Replies to the message, which are themselves messages.
+	Replies []*Message `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Message) UnmarshalJSON(b []byte) error {
+	type message Message
+	root := &struct {
+		*message
+		Replies json.RawMessage `json:"replies"`
+	}{message: (*message)(m)}
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	// like comments, if a message has no replies, its "replies" field is set to ""
+	if len(root.Replies) == 0 || root.Replies[0] != '{' {
+		return nil
+	}
+
+	replies := new(thing)
+	err = json.Unmarshal(root.Replies, replies)
+	if err != nil {
+		return err
+	}
+
+	if l, ok := replies.Listing(); ok {
+		m.Replies = l.things.Messages
+	}
+
+	return nil
 }
 
 type inboxThing struct {
@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -18,7 +19,9 @@ var expectedCommentMessages = []*Message{
 
 		Subject:  "post reply",
 		Text:     "u/testuser2 hello",
+		TextHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;&lt;a href=\"/u/testuser2\"&gt;u/testuser2&lt;/a&gt; hello&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
 		ParentID: "t3_hs03f3",
+		Context:  "/r/helloworldtestt/comments/hs03f3/post_1/g1xi2m9/?context=3",
 
 		Author: "testuser1",
 		To:     "testuser2",
@@ -35,6 +38,7 @@ var expectedMessages = []*Message{
 
 		Subject:  "re: test",
 		Text:     "test",
+		TextHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;test&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
 		ParentID: "t4_qwki4m",
 
 		Author: "testuser1",
@@ -256,3 +260,36 @@ func TestMessageService_Sent(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedMessages, messages)
 }
+
+func TestMessage_UnmarshalJSON_Replies(t *testing.T) {
+	root := new(thing)
+	err := json.Unmarshal([]byte(`{"kind": "Listing", "data": {"children": [
+		{"kind": "t4", "data": {"id": "m1", "name": "t4_m1", "subject": "hello", "body": "first", "new": true, "replies": {"kind": "Listing", "data": {"children": [
+			{"kind": "t4", "data": {"id": "m2", "name": "t4_m2", "subject": "re: hello", "body": "second", "parent_id": "t4_m1", "replies": {"kind": "Listing", "data": {"children": [
+				{"kind": "t4", "data": {"id": "m3", "name": "t4_m3", "subject": "re: hello", "body": "third", "parent_id": "t4_m1", "replies": ""}}
+			]}}}}
+		]}}}},
+		{"kind": "t4", "data": {"id": "m4", "name": "t4_m4", "subject": "other", "body": "fourth", "replies": ""}}
+	]}}`), root)
+	require.NoError(t, err)
+
+	l, ok := root.Listing()
+	require.True(t, ok)
+	require.Len(t, l.things.Messages, 2)
+
+	m1 := l.things.Messages[0]
+	require.Equal(t, "t4_m1", m1.FullID)
+	require.True(t, m1.New)
+	require.Len(t, m1.Replies, 1)
+	require.Equal(t, "second", m1.Replies[0].Text)
+	require.Len(t, m1.Replies[0].Replies, 1)
+	require.Equal(t, "third", m1.Replies[0].Replies[0].Text)
+	require.Nil(t, m1.Replies[0].Replies[0].Replies)
+
+	require.Equal(t, "t4_m4", l.things.Messages[1].FullID)
+	require.Nil(t, l.things.Messages[1].Replies)
+
+	v, ok := l.things.Find("t4_m4")
+	require.True(t, ok)
+	require.Equal(t, "t4", v.Kind())
+}
@@ -2,10 +2,13 @@ package reddit
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -24,6 +27,8 @@ type ModAction struct {
 	ID      string     `json:"id,omitempty"`
 	Action  string     `json:"action,omitempty"`
 	Created *Timestamp `json:"created_utc,omitempty"`
+	// Additional information about the action, e.g. the duration of a ban.
+	Details string `json:"details,omitempty"`
 
 	Moderator string `json:"mod,omitempty"`
 	// Not the full ID, just the ID36.
@@ -42,6 +47,47 @@ type ModAction struct {
 	SubredditID string `json:"sr_id36,omitempty"`
 }
 
+// ModActionsToCSV writes the actions to w in CSV format, with a header row
+// followed by one row per action.
+// The created column is formatted as RFC3339, and left empty if unknown.
+func ModActionsToCSV(w io.Writer, actions []*ModAction) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"created", "mod", "action", "target", "details"})
+	if err != nil {
+		return err
+	}
+
+	for _, action := range actions {
+		var created string
+		if action.Created != nil && !action.Created.IsZero() {
+			created = action.Created.Format(time.RFC3339)
+		}
+
+		err = writer.Write([]string{created, action.Moderator, action.Action, action.TargetID, action.Details})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// BucketModActions counts the actions that happened within each window of time, e.g. each hour,
+// keyed by the start of the window. Windows are aligned to the zero time, like time.Time.Truncate.
+// Actions without a timestamp are left out.
+func BucketModActions(actions []*ModAction, window time.Duration) map[time.Time]int {
+	buckets := make(map[time.Time]int)
+	for _, action := range actions {
+		if action.Created == nil || action.Created.IsZero() {
+			continue
+		}
+		buckets[action.Created.Truncate(window)]++
+	}
+	return buckets
+}
+
 // ModPermissions are the different permissions moderators have or don't have on a subreddit.
 // Read about them here: https://mods.reddithelp.com/hc/en-us/articles/360009381491-User-Management-moderators-and-permissions
 type ModPermissions struct {
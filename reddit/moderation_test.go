@@ -1,6 +1,7 @@
 package reddit
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -15,6 +16,7 @@ var expectedModActions = []*ModAction{
 		ID:      "ModAction_b4e7979a-c4ad-11ea-8440-0ea1b7c2b8f9",
 		Action:  "spamcomment",
 		Created: &Timestamp{time.Date(2020, 7, 13, 2, 8, 14, 0, time.UTC)},
+		Details: "spam",
 
 		Moderator:   "v_95",
 		ModeratorID: "164ab8",
@@ -71,6 +73,46 @@ func TestModerationService_Actions(t *testing.T) {
 	require.Equal(t, "ModAction_a0408162-c4ad-11ea-8239-0e3b48262e8b", resp.After)
 }
 
+func TestModActionsToCSV(t *testing.T) {
+	actions := append([]*ModAction{}, expectedModActions...)
+	actions = append(actions, &ModAction{Action: "banuser", Moderator: "v_95", TargetID: "t2_test", Details: "permanent, \"spam\""})
+
+	buf := new(bytes.Buffer)
+	err := ModActionsToCSV(buf, actions)
+	require.NoError(t, err)
+	require.Equal(t, `created,mod,action,target,details
+2020-07-13T02:08:14Z,v_95,spamcomment,t1_fxw10aa,spam
+2020-07-13T02:07:38Z,v_95,sticky,t3_hq6r3t,
+,v_95,banuser,t2_test,"permanent, ""spam"""
+`, buf.String())
+}
+
+func TestBucketModActions(t *testing.T) {
+	at := func(hour, min int) *Timestamp {
+		return &Timestamp{time.Date(2020, 7, 13, hour, min, 0, 0, time.UTC)}
+	}
+
+	actions := []*ModAction{
+		{Action: "spamcomment", Created: at(2, 8)},
+		{Action: "sticky", Created: at(2, 59)},
+		{Action: "banuser", Created: at(3, 0)},
+		{Action: "removelink", Created: at(5, 30)},
+		{Action: "approvelink"},
+	}
+
+	require.Equal(t, map[time.Time]int{
+		at(2, 0).Time: 2,
+		at(3, 0).Time: 1,
+		at(5, 0).Time: 1,
+	}, BucketModActions(actions, time.Hour))
+
+	require.Equal(t, map[time.Time]int{
+		at(0, 0).Time: 4,
+	}, BucketModActions(actions, 24*time.Hour))
+
+	require.Empty(t, BucketModActions(nil, time.Hour))
+}
+
 func TestModerationService_AcceptInvite(t *testing.T) {
 	client, mux := setup(t)
 
@@ -26,6 +26,9 @@ const (
 )
 
 // Delete a post or comment via its full ID.
+// Reddit answers the same way whether or not the post or comment exists, so deleting one
+// that doesn't exist doesn't return an error. Deleting one of another user returns an
+// error that wraps a *PermissionError.
 func (s *postAndCommentService) Delete(ctx context.Context, id string) (*Response, error) {
 	path := "api/del"
 
@@ -41,11 +44,22 @@ func (s *postAndCommentService) Delete(ctx context.Context, id string) (*Respons
 }
 
 // Save a post or comment.
+// The Saved field of the post or comment, if held locally, is not updated; see Post.SetSaved.
 func (s *postAndCommentService) Save(ctx context.Context, id string) (*Response, error) {
+	return s.SaveToCategory(ctx, id, "")
+}
+
+// SaveToCategory saves a post or comment under the category.
+// Categories are only available to Reddit Premium users.
+// The Saved field of the post or comment, if held locally, is not updated; see Post.SetSaved.
+func (s *postAndCommentService) SaveToCategory(ctx context.Context, id string, category string) (*Response, error) {
 	path := "api/save"
 
 	form := url.Values{}
 	form.Set("id", id)
+	if category != "" {
+		form.Set("category", category)
+	}
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -56,6 +70,7 @@ func (s *postAndCommentService) Save(ctx context.Context, id string) (*Response,
 }
 
 // Unsave a post or comment.
+// The Saved field of the post or comment, if held locally, is not updated; see Post.SetSaved.
 func (s *postAndCommentService) Unsave(ctx context.Context, id string) (*Response, error) {
 	path := "api/unsave"
 
@@ -132,6 +147,7 @@ func (s *postAndCommentService) Unlock(ctx context.Context, id string) (*Respons
 	return s.client.Do(ctx, req, nil)
 }
 
+// vote casts the vote on the post or comment with the full ID.
 func (s *postAndCommentService) vote(ctx context.Context, id string, vote vote) (*Response, error) {
 	path := "api/vote"
 
@@ -149,16 +165,22 @@ func (s *postAndCommentService) vote(ctx context.Context, id string, vote vote)
 }
 
 // Upvote a post or a comment.
+// Reddit's API rules require votes to be cast by a person, not automatically by a bot.
+// The Likes field of the post or comment, if held locally, is not updated.
 func (s *postAndCommentService) Upvote(ctx context.Context, id string) (*Response, error) {
 	return s.vote(ctx, id, upvote)
 }
 
 // Downvote a post or a comment.
+// Reddit's API rules require votes to be cast by a person, not automatically by a bot.
+// The Likes field of the post or comment, if held locally, is not updated.
 func (s *postAndCommentService) Downvote(ctx context.Context, id string) (*Response, error) {
 	return s.vote(ctx, id, downvote)
 }
 
 // RemoveVote removes your vote on a post or a comment.
+// Reddit's API rules require votes to be cast by a person, not automatically by a bot.
+// The Likes field of the post or comment, if held locally, is not updated.
 func (s *postAndCommentService) RemoveVote(ctx context.Context, id string) (*Response, error) {
 	return s.vote(ctx, id, novote)
 }
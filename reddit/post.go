@@ -63,10 +63,31 @@ type SubmitLinkRequest struct {
 }
 
 // Get a post with its comments.
-// id is the ID36 of the post, not its full id.
-// Example: instead of t3_abc123, use abc123.
+// id is the ID36 of the post, e.g. abc123. Its full ID, e.g. t3_abc123, or its permalink are accepted as well.
 func (s *PostService) Get(ctx context.Context, id string) (*PostAndComments, *Response, error) {
-	path := fmt.Sprintf("comments/%s", id)
+	return s.GetWithOptions(ctx, id, nil)
+}
+
+// GetWithOptions gets a post with its comments, like Get, sorted and limited according to opts.
+func (s *PostService) GetWithOptions(ctx context.Context, id string, opts *GetPostOptions) (*PostAndComments, *Response, error) {
+	id36, err := postID36(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts != nil && opts.Sort == "best" {
+		// Reddit calls the best sort confidence
+		o := *opts
+		o.Sort = "confidence"
+		opts = &o
+	}
+
+	path := fmt.Sprintf("comments/%s", id36)
+	path, err = addOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	req, err := s.client.NewRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -81,6 +102,34 @@ func (s *PostService) Get(ctx context.Context, id string) (*PostAndComments, *Re
 	return root, resp, nil
 }
 
+// postID36 returns the ID36 of a post given its ID36, its full ID or its permalink,
+// e.g. abc123, t3_abc123 or https://www.reddit.com/r/test/comments/abc123/title/.
+func postID36(id string) (string, error) {
+	id = strings.TrimSpace(id)
+
+	if !strings.Contains(id, "/") {
+		id = strings.TrimPrefix(id, kindPost+"_")
+		if id == "" {
+			return "", errors.New("id: cannot be empty")
+		}
+		return id, nil
+	}
+
+	u, err := url.Parse(id)
+	if err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment == "comments" && i+1 < len(segments) && segments[i+1] != "" {
+			return segments[i+1], nil
+		}
+	}
+
+	return "", fmt.Errorf("id: %q is not the permalink of a post", id)
+}
+
 // Duplicates returns the post with the id, and a list of its duplicates.
 // id is the ID36 of the post, not its full id.
 // Example: instead of t3_abc123, use abc123.
@@ -464,7 +513,16 @@ func (s *PostService) DisableContestMode(ctx context.Context, id string) (*Respo
 	return s.client.Do(ctx, req, nil)
 }
 
+// maxMoreChildren is the maximum number of comment IDs that can be requested at once
+// from the api/morechildren endpoint.
+const maxMoreChildren = 100
+
 // LoadMoreComments retrieves more comments that were left out when initially fetching the post.
+// The comment IDs are requested in batches of 100, which is the most Reddit allows at once,
+// and the comments are added to the tree as each batch is received.
+// If a batch fails, pc.More is left with the IDs of the comments that weren't loaded,
+// so calling it again picks up where it stopped.
+// The returned response is that of the last batch.
 func (s *PostService) LoadMoreComments(ctx context.Context, pc *PostAndComments) (*Response, error) {
 	if pc == nil {
 		return nil, errors.New("*PostAndComments: cannot be nil")
@@ -477,6 +535,61 @@ func (s *PostService) LoadMoreComments(ctx context.Context, pc *PostAndComments)
 	postID := pc.Post.FullID
 	commentIDs := pc.More.Children
 
+	var resp *Response
+
+	// The mores of the post returned by each batch. They're only merged into pc.More
+	// once all batches are done, since pc.More.Children is still being iterated over.
+	var mores []*More
+	var notLoaded []string
+
+	for len(commentIDs) > 0 {
+		batch := commentIDs
+		if len(batch) > maxMoreChildren {
+			batch = batch[:maxMoreChildren]
+		}
+		commentIDs = commentIDs[len(batch):]
+
+		var t *things
+		var err error
+		t, resp, err = s.moreChildren(ctx, postID, batch)
+		if err != nil {
+			notLoaded = append(notLoaded, batch...)
+			notLoaded = append(notLoaded, commentIDs...)
+			pc.More.Children = notLoaded
+			return resp, err
+		}
+
+		for _, c := range t.Comments {
+			pc.addCommentToTree(c)
+		}
+
+		for _, m := range t.Mores {
+			if m.ParentID == postID {
+				mores = append(mores, m)
+				notLoaded = append(notLoaded, m.Children...)
+				continue
+			}
+			pc.addMoreToTree(m)
+		}
+	}
+
+	if len(mores) == 0 {
+		pc.More = nil
+		return resp, nil
+	}
+
+	more := *mores[0]
+	for _, m := range mores[1:] {
+		more.Count += m.Count
+	}
+	more.Children = notLoaded
+	pc.More = &more
+
+	return resp, nil
+}
+
+// moreChildren gets the comments with the given IDs from the post's tree.
+func (s *PostService) moreChildren(ctx context.Context, postID string, commentIDs []string) (*things, *Response, error) {
 	form := url.Values{}
 	form.Set("api_type", "json")
 	form.Set("link_id", postID)
@@ -488,7 +601,7 @@ func (s *PostService) LoadMoreComments(ctx context.Context, pc *PostAndComments)
 	// since it's in the body and not the URI.
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	root := new(struct {
@@ -500,29 +613,10 @@ func (s *PostService) LoadMoreComments(ctx context.Context, pc *PostAndComments)
 	})
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
-		return resp, err
-	}
-
-	comments := root.JSON.Data.Things.Comments
-	for _, c := range comments {
-		pc.addCommentToTree(c)
-	}
-
-	noMore := true
-
-	mores := root.JSON.Data.Things.Mores
-	for _, m := range mores {
-		if strings.HasPrefix(m.ParentID, kindPost+"_") {
-			noMore = false
-		}
-		pc.addMoreToTree(m)
-	}
-
-	if noMore {
-		pc.More = nil
+		return nil, resp, err
 	}
 
-	return resp, nil
+	return &root.JSON.Data.Things, resp, nil
 }
 
 func (s *PostService) random(ctx context.Context, subreddits ...string) (*PostAndComments, *Response, error) {
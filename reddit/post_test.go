@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -35,6 +36,11 @@ var expectedPostAndComments = &PostAndComments{
 		Author:   "testuser",
 		AuthorID: "t2_testuser",
 
+		Thumbnail: "self",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
 		IsSelfPost: true,
 	},
 	Comments: []*Comment{
@@ -47,9 +53,12 @@ var expectedPostAndComments = &PostAndComments{
 			ParentID:  "t3_testpost",
 			Permalink: "/r/test/comments/testpost/test/testc1/",
 
-			Body:     "Hi",
-			Author:   "testuser",
-			AuthorID: "t2_testuser",
+			Body:                "Hi",
+			Author:              "testuser",
+			AuthorID:            "t2_testuser",
+			AuthorFlairRichText: []FlairSegment{},
+
+			Awardings: []*Award{},
 
 			SubredditName:         "test",
 			SubredditNamePrefixed: "r/test",
@@ -74,9 +83,12 @@ var expectedPostAndComments = &PostAndComments{
 						ParentID:  "t1_testc1",
 						Permalink: "/r/test/comments/testpost/test/testc2/",
 
-						Body:     "Hello",
-						Author:   "testuser",
-						AuthorID: "t2_testuser",
+						Body:                "Hello",
+						Author:              "testuser",
+						AuthorID:            "t2_testuser",
+						AuthorFlairRichText: []FlairSegment{},
+
+						Awardings: []*Award{},
 
 						SubredditName:         "test",
 						SubredditNamePrefixed: "r/test",
@@ -89,6 +101,8 @@ var expectedPostAndComments = &PostAndComments{
 
 						IsSubmitter: true,
 						CanGild:     true,
+
+						depth: 1,
 					},
 				},
 			},
@@ -128,6 +142,11 @@ var expectedEditedPost = &Post{
 	Author:   "v_95",
 	AuthorID: "t2_164ab8",
 
+	Thumbnail: "spoiler",
+
+	Awardings:        []*Award{},
+	IsRobotIndexable: true,
+
 	Spoiler:    true,
 	IsSelfPost: true,
 }
@@ -156,6 +175,11 @@ var expectedPost2 = &Post{
 
 	Author:   "v_95",
 	AuthorID: "t2_164ab8",
+
+	Thumbnail: "default",
+
+	Awardings:        []*Award{},
+	IsRobotIndexable: true,
 }
 
 var expectedPostDuplicates = []*Post{
@@ -183,6 +207,12 @@ var expectedPostDuplicates = []*Post{
 
 		Author:   "GarlicoinAccount",
 		AuthorID: "t2_d2v1r90",
+
+		Thumbnail: "default",
+
+		Archived:         true,
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
 	},
 	{
 		ID:      "le1tc",
@@ -208,6 +238,12 @@ var expectedPostDuplicates = []*Post{
 
 		Author:   "prog101",
 		AuthorID: "t2_8dyo",
+
+		Thumbnail: "default",
+
+		Archived:         true,
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
 	},
 }
 
@@ -227,6 +263,42 @@ func TestPostService_Get(t *testing.T) {
 	require.Equal(t, expectedPostAndComments, postAndComments)
 }
 
+func TestPostService_GetWithOptions(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/post/post.json")
+	require.NoError(t, err)
+
+	var queries []url.Values
+	mux.HandleFunc("/comments/abc123", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		queries = append(queries, r.URL.Query())
+		fmt.Fprint(w, blob)
+	})
+
+	postAndComments, _, err := client.Post.GetWithOptions(ctx, "t3_abc123", &GetPostOptions{Sort: "best", Depth: 2, Limit: 50})
+	require.NoError(t, err)
+	require.Equal(t, expectedPostAndComments, postAndComments)
+
+	_, _, err = client.Post.GetWithOptions(ctx, "https://www.reddit.com/r/test/comments/abc123/test_title/", &GetPostOptions{Sort: "qa"})
+	require.NoError(t, err)
+
+	_, _, err = client.Post.Get(ctx, "/r/test/comments/abc123/")
+	require.NoError(t, err)
+
+	require.Equal(t, []url.Values{
+		{"sort": {"confidence"}, "depth": {"2"}, "limit": {"50"}},
+		{"sort": {"qa"}},
+		{},
+	}, queries)
+
+	_, _, err = client.Post.Get(ctx, "https://www.reddit.com/r/test/")
+	require.EqualError(t, err, `id: "https://www.reddit.com/r/test/" is not the permalink of a post`)
+
+	_, _, err = client.Post.Get(ctx, "t3_")
+	require.EqualError(t, err, "id: cannot be empty")
+}
+
 func TestPostService_Duplicates(t *testing.T) {
 	client, mux := setup(t)
 
@@ -293,6 +365,30 @@ func TestPostService_SubmitText(t *testing.T) {
 	require.Equal(t, expectedSubmittedPost, submittedPost)
 }
 
+func TestPostService_SubmitText_Error(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		fmt.Fprint(w, `{
+			"json": {
+				"errors": [
+					["RATELIMIT", "you are doing that too much. try again in 9 minutes.", "ratelimit"]
+				]
+			}
+		}`)
+	})
+
+	_, _, err := client.Post.SubmitText(ctx, SubmitTextRequest{
+		Subreddit: "test",
+		Title:     "Test Title",
+		Text:      "Test Text",
+	})
+	require.IsType(t, &JSONErrorResponse{}, err)
+	require.True(t, err.(*JSONErrorResponse).HasLabel(ErrorLabelRateLimit))
+	require.False(t, err.(*JSONErrorResponse).HasLabel(ErrorLabelSubredditNotAllowed))
+}
+
 func TestPostService_SubmitLink(t *testing.T) {
 	client, mux := setup(t)
 
@@ -331,6 +427,48 @@ func TestPostService_SubmitLink(t *testing.T) {
 	require.Equal(t, expectedSubmittedPost, submittedPost)
 }
 
+func TestPostService_SubmitLink_AlreadySubmitted(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/post/submit.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+
+		if r.PostForm.Get("resubmit") != "true" {
+			fmt.Fprint(w, `{
+				"json": {
+					"errors": [
+						["ALREADY_SUB", "that link has already been submitted", "url"]
+					]
+				}
+			}`)
+			return
+		}
+
+		fmt.Fprint(w, blob)
+	})
+
+	opts := SubmitLinkRequest{
+		Subreddit: "test",
+		Title:     "Test Title",
+		URL:       "https://www.example.com",
+	}
+
+	_, _, err = client.Post.SubmitLink(ctx, opts)
+	require.IsType(t, &JSONErrorResponse{}, err)
+	require.True(t, err.(*JSONErrorResponse).HasLabel(ErrorLabelAlreadySubmitted))
+
+	opts.Resubmit = true
+	submittedPost, _, err := client.Post.SubmitLink(ctx, opts)
+	require.NoError(t, err)
+	require.Equal(t, expectedSubmittedPost, submittedPost)
+}
+
 func TestPostService_Edit(t *testing.T) {
 	client, mux := setup(t)
 
@@ -847,6 +985,80 @@ func TestPostService_LoadMoreReplies(t *testing.T) {
 	require.Len(t, pc.Comments[0].Replies.Comments[0].Replies.Comments, 1)
 }
 
+func TestPostService_LoadMoreComments_Batches(t *testing.T) {
+	client, mux := setup(t)
+
+	children := make([]string, 150)
+	for i := range children {
+		children[i] = fmt.Sprintf("c%d", i)
+	}
+
+	var batches [][]string
+	mux.HandleFunc("/api/morechildren", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, "t3_123", r.PostForm.Get("link_id"))
+
+		batch := strings.Split(r.PostForm.Get("children"), ",")
+		batches = append(batches, batch)
+
+		fmt.Fprintf(w, `{"json": {"data": {"things": [{"kind": "t1", "data": {"name": "t1_%s", "parent_id": "t3_123"}}]}}}`, batch[0])
+	})
+
+	pc := &PostAndComments{
+		Post: &Post{FullID: "t3_123"},
+		More: &More{Children: children},
+	}
+
+	_, err := client.Post.LoadMoreComments(ctx, pc)
+	require.NoError(t, err)
+	require.Equal(t, [][]string{children[:100], children[100:]}, batches)
+	require.Len(t, pc.Comments, 2)
+	require.Equal(t, "t1_c0", pc.Comments[0].FullID)
+	require.Equal(t, "t1_c100", pc.Comments[1].FullID)
+	require.False(t, pc.HasMore())
+}
+
+func TestPostService_LoadMoreComments_BatchFails(t *testing.T) {
+	client, mux := setup(t)
+
+	children := make([]string, 250)
+	for i := range children {
+		children[i] = fmt.Sprintf("c%d", i)
+	}
+
+	requests := 0
+	mux.HandleFunc("/api/morechildren", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+
+		batch := strings.Split(r.PostForm.Get("children"), ",")
+		fmt.Fprintf(w, `{"json": {"data": {"things": [
+			{"kind": "t1", "data": {"name": "t1_%s", "parent_id": "t3_123"}},
+			{"kind": "more", "data": {"name": "t1_more", "parent_id": "t3_123", "count": 1, "children": ["m0"]}}
+		]}}}`, batch[0])
+	})
+
+	pc := &PostAndComments{
+		Post: &Post{FullID: "t3_123"},
+		More: &More{Children: children},
+	}
+
+	_, err := client.Post.LoadMoreComments(ctx, pc)
+	require.Error(t, err)
+	require.Len(t, pc.Comments, 1)
+	require.True(t, pc.HasMore())
+	require.Equal(t, append([]string{"m0"}, children[100:]...), pc.More.Children)
+}
+
 func TestPostService_RandomFromSubreddits(t *testing.T) {
 	client, mux := setup(t)
 
@@ -933,6 +1145,26 @@ func TestPostService_Save(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestPostService_SaveToCategory(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/save", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		form := url.Values{}
+		form.Set("id", "t3_test")
+		form.Set("category", "recipes")
+
+		err := r.ParseForm()
+		require.NoError(t, err)
+		require.Equal(t, form, r.PostForm)
+	})
+
+	resp, err := client.Post.SaveToCategory(ctx, "t3_test", "recipes")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestPostService_Unsave(t *testing.T) {
 	client, mux := setup(t)
 
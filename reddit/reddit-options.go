@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 )
 
 // Opt is used to further configure a client upon initialization.
@@ -55,6 +56,29 @@ func WithTokenURL(u string) Opt {
 	}
 }
 
+// WithRetry sets the client to retry requests up to max times when Reddit responds with
+// 429 Too Many Requests, or with a 500, 502 or 503 status code to a GET, HEAD, OPTIONS, PUT
+// or DELETE request. Other requests, such as POSTs, might have been processed despite
+// the server error, so they aren't retried in that case.
+// Rate limited requests are retried once the time given in the Retry-After or
+// X-Ratelimit-Reset header has passed, unless it is more than a minute away, in which
+// case the error is returned right away. Otherwise, the wait starts at base and doubles
+// with each attempt, with some jitter, up to a minute. Other errors are returned right away.
+// If the retries are exhausted, the error of the last attempt is returned.
+func WithRetry(max int, base time.Duration) Opt {
+	return func(c *Client) error {
+		if max < 0 {
+			return errors.New("max: cannot be negative")
+		}
+		if max > 0 && base <= 0 {
+			return errors.New("base: must be positive")
+		}
+		c.retryMax = max
+		c.retryBase = base
+		return nil
+	}
+}
+
 // FromEnv configures the client with values from environment variables.
 // Supported environment variables:
 // GO_REDDIT_CLIENT_ID to set the client's id.
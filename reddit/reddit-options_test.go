@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +53,19 @@ func TestWithTokenURL(t *testing.T) {
 	require.Equal(t, tokenURL, c.TokenURL.String())
 }
 
+func TestWithRetry(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithRetry(-1, time.Second))
+	require.EqualError(t, err, "max: cannot be negative")
+
+	_, err = NewClient(Credentials{}, WithRetry(3, 0))
+	require.EqualError(t, err, "base: must be positive")
+
+	c, err := NewClient(Credentials{}, WithRetry(3, time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 3, c.retryMax)
+	require.Equal(t, time.Second, c.retryBase)
+}
+
 func TestFromEnv(t *testing.T) {
 	os.Setenv("GO_REDDIT_CLIENT_ID", "id1")
 	defer os.Unsetenv("GO_REDDIT_CLIENT_ID")
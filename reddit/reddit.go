@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,6 +40,7 @@ const (
 	headerRateLimitRemaining = "x-ratelimit-remaining"
 	headerRateLimitUsed      = "x-ratelimit-used"
 	headerRateLimitReset     = "x-ratelimit-reset"
+	headerRetryAfter         = "retry-after"
 )
 
 var defaultClient, _ = NewReadonlyClient()
@@ -98,6 +102,10 @@ type Client struct {
 
 	oauth2Transport *oauth2.Transport
 
+	// Retry policy set with WithRetry. Requests are not retried by default.
+	retryMax  int
+	retryBase time.Duration
+
 	onRequestCompleted RequestCompletionCallback
 }
 
@@ -345,7 +353,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 		}, err
 	}
 
-	resp, err := DoRequestWithClient(ctx, c.client, req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -387,6 +395,14 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return response, nil
 }
 
+// LastRate returns the rate limit reported by Reddit with the most recent response,
+// e.g. to slow down before running out of requests.
+func (c *Client) LastRate() Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rate
+}
+
 func (c *Client) checkRateLimitBeforeDo(req *http.Request) *RateLimitError {
 	c.rateMu.Lock()
 	rate := c.rate
@@ -431,6 +447,103 @@ func DoRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	return DoRequestWithClient(ctx, http.DefaultClient, req)
 }
 
+// doWithRetry sends the request, retrying it according to the client's retry policy
+// when Reddit responds with 429, or with a 500, 502 or 503 status code to an idempotent request.
+// The response of the last attempt is returned once the retries are exhausted.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := DoRequestWithClient(ctx, c.client, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt >= c.retryMax || !isRetryable(req.Method, resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := c.retryDelay(resp, attempt)
+		if delay > maxRetryDelay {
+			// rather than blocking for that long, let the caller decide what to do
+			return resp, nil
+		}
+		// drain the body so the connection can be reused
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryable reports whether a request with the method that got the status code can be retried.
+// A rate limited request wasn't processed, so it can always be retried. A server error might have
+// happened after a request was processed, so it is only retried if sending it again is harmless.
+func isRetryable(method string, code int) bool {
+	switch code {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// maxRetryDelay is the longest the client waits before retrying a request.
+// A rate limited request that Reddit asks to wait longer for is not retried.
+const maxRetryDelay = time.Minute
+
+// retryDelay returns how long to wait before retrying a request after the given response.
+// Rate limited responses are retried once the time given by Reddit has passed.
+// Otherwise, the delay grows exponentially with each attempt, with some jitter,
+// up to maxRetryDelay.
+func (c *Client) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Retry-After can also be an HTTP date, which Reddit doesn't use. It is ignored,
+		// so the backoff is used unless X-Ratelimit-Reset is set.
+		for _, header := range []string{headerRetryAfter, headerRateLimitReset} {
+			if v, err := strconv.ParseFloat(resp.Header.Get(header), 64); err == nil && v >= 0 {
+				return time.Duration(v * float64(time.Second))
+			}
+		}
+	}
+
+	delay := c.retryBase << uint(attempt)
+	if delay <= 0 || delay > maxRetryDelay {
+		// also guards against overflows
+		delay = maxRetryDelay
+	}
+	// use a random delay between half and the full backoff
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
 // DoRequestWithClient submits an HTTP request using the specified client.
 func DoRequestWithClient(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
 	req = req.WithContext(ctx)
@@ -537,6 +650,27 @@ type ListOptions struct {
 	Before string `url:"before,omitempty"`
 }
 
+// fullIDRegexp matches the full ID of a thing, e.g. t3_abc123.
+var fullIDRegexp = regexp.MustCompile(`^t[1-9]_[0-9a-z]+$`)
+
+// Validate checks that the anchors of the options are usable.
+// At most one of After and Before can be set, and it must be a full ID, e.g. t3_abc123.
+func (o *ListOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.After != "" && o.Before != "" {
+		return errors.New("*ListOptions: After and Before cannot both be set")
+	}
+	if o.After != "" && !fullIDRegexp.MatchString(o.After) {
+		return fmt.Errorf("(*ListOptions).After: %q is not a full ID", o.After)
+	}
+	if o.Before != "" && !fullIDRegexp.MatchString(o.Before) {
+		return fmt.Errorf("(*ListOptions).Before: %q is not a full ID", o.Before)
+	}
+	return nil
+}
+
 // ListSubredditOptions defines possible options used when searching for subreddits.
 type ListSubredditOptions struct {
 	ListOptions
@@ -580,6 +714,17 @@ type ListDuplicatePostOptions struct {
 	CrosspostsOnly bool `url:"crossposts_only,omitempty"`
 }
 
+// GetPostOptions defines possible options used when getting a post with its comments.
+type GetPostOptions struct {
+	// How to sort the comments.
+	// One of: best (or confidence), top, new, controversial, old, qa.
+	Sort string `url:"sort,omitempty"`
+	// Maximum depth of the comment tree to return.
+	Depth int `url:"depth,omitempty"`
+	// Maximum number of comments to return.
+	Limit int `url:"limit,omitempty"`
+}
+
 // ListModActionOptions defines possible options used when getting moderation actions in a subreddit.
 type ListModActionOptions struct {
 	// The max for the limit parameter here is 500.
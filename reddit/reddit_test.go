@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
 	"testing"
@@ -200,6 +201,10 @@ func TestClient_JSONErrorResponse(t *testing.T) {
 	require.IsType(t, &JSONErrorResponse{}, err)
 	require.EqualError(t, err, fmt.Sprintf(`GET %s/api/v1/test: 200 field "test field" caused TEST_ERROR: this is a test error`, client.BaseURL))
 	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, &APIError{Label: "TEST_ERROR", Reason: "this is a test error", Field: "test field"}, apiErr)
 }
 
 func TestClient_ErrorResponse(t *testing.T) {
@@ -219,6 +224,8 @@ func TestClient_ErrorResponse(t *testing.T) {
 	resp, err := client.Do(ctx, req, nil)
 	require.IsType(t, &ErrorResponse{}, err)
 	require.EqualError(t, err, fmt.Sprintf(`GET %s/api/v1/test: 403 error message`, client.BaseURL))
+	require.True(t, err.(*ErrorResponse).Forbidden())
+	require.True(t, errors.As(err, new(*PermissionError)))
 	require.Equal(t, http.StatusForbidden, resp.StatusCode)
 }
 
@@ -271,3 +278,214 @@ func TestClient_Do_RateLimitError(t *testing.T) {
 	require.Equal(t, 600, resp.Rate.Used)
 	require.Equal(t, time.Now().Truncate(time.Second).Add(time.Minute*4), resp.Rate.Reset)
 }
+
+func TestClient_LastRate(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set(headerRateLimitRemaining, "500")
+		w.Header().Set(headerRateLimitUsed, "100")
+		w.Header().Set(headerRateLimitReset, "120")
+	})
+
+	require.Equal(t, Rate{}, client.LastRate())
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, resp.Rate, client.LastRate())
+	require.Equal(t, 500, client.LastRate().Remaining)
+	require.Equal(t, 100, client.LastRate().Used)
+}
+
+func TestClient_Do_Retry(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 3
+	client.retryBase = time.Millisecond
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseForm())
+		// the body is sent again with each attempt
+		require.Equal(t, "value", r.Form.Get("key"))
+		defer func() { counter++ }()
+
+		switch counter {
+		case 0:
+			w.Header().Set(headerRetryAfter, "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 1:
+			w.Header().Set(headerRateLimitReset, "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"ok": true}`)
+		}
+	})
+
+	req, err := client.NewRequest(http.MethodPost, "api/v1/test", url.Values{"key": {"value"}})
+	require.NoError(t, err)
+
+	var result map[string]bool
+	resp, err := client.Do(ctx, req, &result)
+	require.NoError(t, err)
+	require.Equal(t, 3, counter)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, map[string]bool{"ok": true}, result)
+}
+
+func TestClient_Do_RetrySkipsServerErrorsOfPosts(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 3
+	client.retryBase = time.Millisecond
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, err := client.NewRequest(http.MethodPost, "api/v1/test", url.Values{"key": {"value"}})
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, 1, counter)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestClient_Do_RetrySkipsLongRateLimits(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 3
+	client.retryBase = time.Millisecond
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.Header().Set(headerRetryAfter, "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, 1, counter)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.True(t, time.Since(start) < maxRetryDelay)
+}
+
+func TestClient_Do_RetryExhausted(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 2
+	client.retryBase = time.Millisecond
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprintf(w, `{"message": "attempt %d"}`, counter)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.Equal(t, 3, counter)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.EqualError(t, err, fmt.Sprintf(`GET %s/api/v1/test: 502 attempt 3`, client.BaseURL))
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestClient_Do_RetrySkipsClientErrors(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 3
+	client.retryBase = time.Millisecond
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.Equal(t, 1, counter)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestClient_Do_RetryContextCanceled(t *testing.T) {
+	client, mux := setup(t)
+	client.retryMax = 3
+	client.retryBase = time.Hour
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Do(ctx, req, nil)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestClient_retryDelay(t *testing.T) {
+	client := &Client{retryBase: time.Second}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set(headerRateLimitReset, "30")
+	require.Equal(t, 30*time.Second, client.retryDelay(resp, 0))
+
+	// Retry-After takes precedence
+	resp.Header.Set(headerRetryAfter, "5")
+	require.Equal(t, 5*time.Second, client.retryDelay(resp, 0))
+
+	// the HTTP date form of Retry-After is ignored
+	resp.Header.Set(headerRetryAfter, "Wed, 21 Oct 2015 07:28:00 GMT")
+	require.Equal(t, 30*time.Second, client.retryDelay(resp, 0))
+
+	resp = &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		delay := client.retryDelay(resp, attempt)
+		require.True(t, delay >= max/2 && delay <= max, "attempt %d: %s", attempt, delay)
+	}
+
+	// the backoff doesn't grow past maxRetryDelay
+	delay := client.retryDelay(resp, 20)
+	require.True(t, delay >= maxRetryDelay/2 && delay <= maxRetryDelay, delay)
+}
+
+func TestListOptions_Validate(t *testing.T) {
+	var opts *ListOptions
+	require.NoError(t, opts.Validate())
+
+	opts = &ListOptions{Limit: 10}
+	require.NoError(t, opts.Validate())
+
+	opts = &ListOptions{After: "t3_abc123"}
+	require.NoError(t, opts.Validate())
+
+	opts = &ListOptions{Before: "t1_def456"}
+	require.NoError(t, opts.Validate())
+
+	opts = &ListOptions{After: "t3_abc123", Before: "t3_def456"}
+	require.EqualError(t, opts.Validate(), "*ListOptions: After and Before cannot both be set")
+
+	opts = &ListOptions{After: "abc123"}
+	require.EqualError(t, opts.Validate(), `(*ListOptions).After: "abc123" is not a full ID`)
+
+	opts = &ListOptions{Before: "t3_"}
+	require.EqualError(t, opts.Validate(), `(*ListOptions).Before: "t3_" is not a full ID`)
+}
@@ -2,6 +2,7 @@ package reddit
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -18,6 +19,8 @@ type StreamService struct {
 //   - a function that the client can call once to stop the streaming and close the channels
 // Because of the 100 post limit imposed by Reddit when fetching posts, some high-traffic
 // streams might drop submissions between API requests, such as when streaming r/all.
+// With StreamDiscardInitial, none of the posts of the first request are sent. After that,
+// every post that wasn't sent before is, even if it comes after one that was.
 func (s *StreamService) Posts(subreddit string, opts ...StreamOpt) (<-chan *Post, <-chan error, func()) {
 	streamConfig := &streamConfig{
 		Interval:       defaultStreamInterval,
@@ -28,72 +31,145 @@ func (s *StreamService) Posts(subreddit string, opts ...StreamOpt) (<-chan *Post
 		opt(streamConfig)
 	}
 
-	ticker := time.NewTicker(streamConfig.Interval)
 	postsCh := make(chan *Post)
+	errsCh, stop := stream(streamConfig, func() ([]string, func(int), error) {
+		posts, err := s.getPosts(subreddit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids := make([]string, len(posts))
+		for i, post := range posts {
+			ids[i] = post.FullID
+		}
+		return ids, func(i int) { postsCh <- posts[i] }, nil
+	}, func() { close(postsCh) })
+
+	return postsCh, errsCh, stop
+}
+
+func (s *StreamService) getPosts(subreddit string) ([]*Post, error) {
+	posts, _, err := s.client.Subreddit.NewPosts(context.Background(), subreddit, &ListOptions{Limit: 100})
+	return posts, err
+}
+
+// Comments streams comments from the specified subreddit.
+// It returns 2 channels and a function:
+//
+//   - a channel into which new comments will be sent
+//   - a channel into which any errors will be sent
+//   - a function that the client can call once to stop the streaming and close the channels
+//
+// Busy subreddits get many comments, so the comments that exist when the stream starts
+// are never sent, only those posted after it.
+func (s *StreamService) Comments(subreddit string, opts ...StreamOpt) (<-chan *Comment, <-chan error, func()) {
+	streamConfig := &streamConfig{
+		Interval:       defaultStreamInterval,
+		DiscardInitial: true,
+		MaxRequests:    0,
+	}
+	for _, opt := range opts {
+		opt(streamConfig)
+	}
+
+	commentsCh := make(chan *Comment)
+	errsCh, stop := stream(streamConfig, func() ([]string, func(int), error) {
+		comments, err := s.getComments(subreddit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids := make([]string, len(comments))
+		for i, comment := range comments {
+			ids[i] = comment.FullID
+		}
+		return ids, func(i int) { commentsCh <- comments[i] }, nil
+	}, func() { close(commentsCh) })
+
+	return commentsCh, errsCh, stop
+}
+
+func (s *StreamService) getComments(subreddit string) ([]*Comment, error) {
+	path := fmt.Sprintf("r/%s/comments", subreddit)
+	l, _, err := s.client.getListing(context.Background(), path, &ListOptions{Limit: 100})
+	if err != nil {
+		return nil, err
+	}
+	return l.Comments(), nil
+}
+
+// streamFetchFunc fetches the latest items of a stream. It returns their full IDs,
+// and a function that sends the item with the ID at the given index into the stream.
+type streamFetchFunc func() (ids []string, send func(i int), err error)
+
+// stream calls fetch at the configured interval and sends the items it returns that
+// haven't been sent before. It returns a channel into which any errors will be sent,
+// and a function that stops the stream, and closes that channel and, using closeItems,
+// the channel the items are sent into.
+func stream(config *streamConfig, fetch streamFetchFunc, closeItems func()) (<-chan error, func()) {
+	ticker := time.NewTicker(config.Interval)
 	errsCh := make(chan error)
 
 	var once sync.Once
 	stop := func() {
 		once.Do(func() {
 			ticker.Stop()
-			close(postsCh)
+			closeItems()
 			close(errsCh)
 		})
 	}
 
-	// originally used the "before" parameter, but if that post gets deleted, subsequent requests
-	// would just return empty listings; easier to just keep track of all post ids encountered
-	ids := set{}
+	// originally used the "before" parameter, but if that item gets deleted, subsequent requests
+	// would just return empty listings; easier to just keep track of the item ids encountered
+	ids := newBoundedSet(streamSeenLimit)
 
 	go func() {
 		defer stop()
 
 		var n int
-		infinite := streamConfig.MaxRequests == 0
+		infinite := config.MaxRequests == 0
+		discard := config.DiscardInitial
 
 		for ; ; <-ticker.C {
 			n++
 
-			posts, err := s.getPosts(subreddit)
+			page, send, err := fetch()
 			if err != nil {
 				errsCh <- err
-				if !infinite && n >= streamConfig.MaxRequests {
+				if !infinite && n >= config.MaxRequests {
 					break
 				}
 				continue
 			}
 
-			for _, post := range posts {
-				id := post.FullID
-
-				// if this post id is already part of the set, it means that it and the ones
-				// after it in the list have already been streamed, so break out of the loop
+			for i, id := range page {
+				// items can be removed from the listing between requests, so an item
+				// having been seen doesn't mean the ones after it have been too
 				if ids.Exists(id) {
-					break
+					continue
 				}
 				ids.Add(id)
 
-				if streamConfig.DiscardInitial {
-					streamConfig.DiscardInitial = false
-					break
+				if !discard {
+					send(i)
 				}
-
-				postsCh <- post
 			}
+			// only the first page is discarded, and all of it
+			discard = false
 
-			if !infinite && n >= streamConfig.MaxRequests {
+			if !infinite && n >= config.MaxRequests {
 				break
 			}
 		}
 	}()
 
-	return postsCh, errsCh, stop
+	return errsCh, stop
 }
 
-func (s *StreamService) getPosts(subreddit string) ([]*Post, error) {
-	posts, _, err := s.client.Subreddit.NewPosts(context.Background(), subreddit, &ListOptions{Limit: 100})
-	return posts, err
-}
+// streamSeenLimit is the number of most recent ids a stream remembers to avoid sending
+// the same item twice. Each request returns at most 100 items, so this covers many
+// requests' worth while keeping memory bounded on long running streams.
+const streamSeenLimit = 1000
 
 type set map[string]struct{}
 
@@ -113,3 +189,26 @@ func (s set) Exists(v string) bool {
 	_, ok := s[v]
 	return ok
 }
+
+// boundedSet is a set that only keeps the max most recently added values.
+type boundedSet struct {
+	set
+	order []string
+	max   int
+}
+
+func newBoundedSet(max int) *boundedSet {
+	return &boundedSet{set: set{}, max: max}
+}
+
+func (s *boundedSet) Add(v string) {
+	if s.Exists(v) {
+		return
+	}
+	if len(s.order) >= s.max {
+		s.set.Delete(s.order[0])
+		s.order = s.order[1:]
+	}
+	s.set.Add(v)
+	s.order = append(s.order, v)
+}
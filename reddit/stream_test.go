@@ -308,3 +308,144 @@ loop:
 
 	require.Len(t, expectedPostIDs, i)
 }
+
+func TestStreamService_Posts_SkipsSeen(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/r/testsubreddit/new", func(w http.ResponseWriter, r *http.Request) {
+		defer func() { counter++ }()
+
+		switch counter {
+		case 0:
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t3", "data": {"name": "t3_post2"}},
+				{"kind": "t3", "data": {"name": "t3_post1"}}
+			]}}`)
+		case 1:
+			// post2 was deleted
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t3", "data": {"name": "t3_post3"}},
+				{"kind": "t3", "data": {"name": "t3_post1"}}
+			]}}`)
+		case 2:
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t3", "data": {"name": "t3_post4"}},
+				{"kind": "t3", "data": {"name": "t3_post3"}},
+				{"kind": "t3", "data": {"name": "t3_post5"}}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	posts, errs, stop := client.Stream.Posts("testsubreddit", StreamInterval(time.Millisecond*10), StreamMaxRequests(3), StreamDiscardInitial)
+	defer stop()
+
+	expectedPostIDs := []string{"t3_post3", "t3_post4", "t3_post5"}
+	var i int
+
+loop:
+	for i != len(expectedPostIDs) {
+		select {
+		case post, ok := <-posts:
+			if !ok {
+				break loop
+			}
+			require.Equal(t, expectedPostIDs[i], post.FullID)
+		case err, ok := <-errs:
+			if !ok {
+				break loop
+			}
+			require.NoError(t, err)
+		}
+		i++
+	}
+
+	require.Len(t, expectedPostIDs, i)
+
+	// the stream ends without sending anything else
+	_, ok := <-posts
+	require.False(t, ok)
+}
+
+func TestBoundedSet(t *testing.T) {
+	s := newBoundedSet(3)
+	s.Add("a")
+	s.Add("b")
+	s.Add("c")
+	s.Add("a")
+	require.Equal(t, 3, s.Len())
+
+	s.Add("d")
+	require.Equal(t, 3, s.Len())
+	require.False(t, s.Exists("a"))
+	require.True(t, s.Exists("b"))
+	require.True(t, s.Exists("c"))
+	require.True(t, s.Exists("d"))
+
+	for i := 0; i < 100; i++ {
+		s.Add(fmt.Sprintf("id%d", i))
+	}
+	require.Equal(t, 3, s.Len())
+	require.True(t, s.Exists("id99"))
+	require.False(t, s.Exists("id96"))
+}
+
+func TestStreamService_Comments(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/r/testsubreddit/comments", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+		defer func() { counter++ }()
+
+		switch counter {
+		case 0:
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t1", "data": {"name": "t1_comment2"}},
+				{"kind": "t1", "data": {"name": "t1_comment1"}}
+			]}}`)
+		case 1:
+			// comment2 was deleted
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t1", "data": {"name": "t1_comment4"}},
+				{"kind": "t1", "data": {"name": "t1_comment3"}},
+				{"kind": "t1", "data": {"name": "t1_comment1"}}
+			]}}`)
+		case 2:
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t1", "data": {"name": "t1_comment4"}},
+				{"kind": "t1", "data": {"name": "t1_comment5"}}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	comments, errs, stop := client.Stream.Comments("testsubreddit", StreamInterval(time.Millisecond*10), StreamMaxRequests(3))
+	defer stop()
+
+	expectedCommentIDs := []string{"t1_comment4", "t1_comment3", "t1_comment5"}
+	var i int
+
+loop:
+	for i != len(expectedCommentIDs) {
+		select {
+		case comment, ok := <-comments:
+			if !ok {
+				break loop
+			}
+			require.Equal(t, expectedCommentIDs[i], comment.FullID)
+		case err, ok := <-errs:
+			if !ok {
+				break loop
+			}
+			require.NoError(t, err)
+		}
+		i++
+	}
+
+	require.Len(t, expectedCommentIDs, i)
+}
@@ -333,6 +333,58 @@ func (s *SubredditService) NewPosts(ctx context.Context, subreddit string, opts
 	return s.getPosts(ctx, "new", subreddit, opts)
 }
 
+// Paginator pages through a listing of posts, carrying the after anchor
+// returned with each page into the request for the next one.
+type Paginator struct {
+	fetch func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error)
+	opts  ListOptions
+	done  bool
+}
+
+// NewPostsPaginator returns a Paginator over the newest posts from the specified subreddit,
+// starting from opts, which may be nil. opts itself is not modified.
+func (s *SubredditService) NewPostsPaginator(subreddit string, opts *ListOptions) *Paginator {
+	p := &Paginator{
+		fetch: func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error) {
+			return s.NewPosts(ctx, subreddit, opts)
+		},
+	}
+	if opts != nil {
+		p.opts = *opts
+	}
+	return p
+}
+
+// Next returns the next page of posts.
+// Once the last page has been returned, i.e. one without an after anchor, it returns io.EOF.
+// If ctx is done, or the options given to the paginator are invalid (see ListOptions.Validate),
+// it returns the error without making a request.
+func (p *Paginator) Next(ctx context.Context) ([]*Post, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := p.opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	posts, resp, err := p.fetch(ctx, &p.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the following pages come after this one, so only the after anchor applies to them
+	p.opts.After = resp.After
+	p.opts.Before = ""
+	if p.opts.After == "" {
+		p.done = true
+	}
+
+	return posts, nil
+}
+
 // RisingPosts returns the rising posts from the specified subreddit.
 // To search through multiple, separate the names with a plus (+), e.g. "golang+test".
 // If none are defined, it returns the ones from your subscribed subreddits.
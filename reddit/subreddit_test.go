@@ -2,6 +2,8 @@ package reddit
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -40,6 +42,12 @@ var expectedPosts = []*Post{
 		Author:   "kmiller0112",
 		AuthorID: "t2_30a5ktgt",
 
+		Thumbnail: "self",
+
+		Archived:         true,
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
+
 		IsSelfPost: true,
 		Stickied:   true,
 	},
@@ -65,6 +73,26 @@ var expectedPosts = []*Post{
 
 		Author:   "MuckleMcDuckle",
 		AuthorID: "t2_6fqntbwq",
+
+		Thumbnail: "https://b.thumbs.redditmedia.com/rg4Aa--ZrHz2PNrmZbBk1cxajQrkRv2cvx2uhp7SSFo.jpg",
+		Preview: &Preview{
+			Images: []*PreviewImage{
+				{
+					ID:     "bxde3rpzP-mqawZJwpBIzEiH1y9nOLW3n1ghq9FPAR8",
+					Source: &PreviewSource{URL: "https://external-preview.redd.it/ljFZZBn60orDIFTvDbPCXM-Thg9XsXAVm5kmH62gxKw.png?auto=webp&amp;s=f5103946eee4586cba8a1ba410e3098e9a14bb58", Width: 720, Height: 859},
+					Resolutions: []*PreviewSource{
+						{URL: "https://external-preview.redd.it/ljFZZBn60orDIFTvDbPCXM-Thg9XsXAVm5kmH62gxKw.png?width=108&amp;crop=smart&amp;auto=webp&amp;s=a6904af790568dcea8fd3566e5d469df88a3891d", Width: 108, Height: 128},
+						{URL: "https://external-preview.redd.it/ljFZZBn60orDIFTvDbPCXM-Thg9XsXAVm5kmH62gxKw.png?width=216&amp;crop=smart&amp;auto=webp&amp;s=09720b85b3b469b37030db3e3a5ab7fa231480f9", Width: 216, Height: 257},
+						{URL: "https://external-preview.redd.it/ljFZZBn60orDIFTvDbPCXM-Thg9XsXAVm5kmH62gxKw.png?width=320&amp;crop=smart&amp;auto=webp&amp;s=78ace2e1c15e0e82dcfc95574d3ea3756812fd98", Width: 320, Height: 381},
+						{URL: "https://external-preview.redd.it/ljFZZBn60orDIFTvDbPCXM-Thg9XsXAVm5kmH62gxKw.png?width=640&amp;crop=smart&amp;auto=webp&amp;s=d5d5305e3d97553176170ead8462cc0d155a7793", Width: 640, Height: 763},
+					},
+				},
+			},
+			Enabled: true,
+		},
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
 	},
 }
 
@@ -73,12 +101,15 @@ var expectedSubreddit = &Subreddit{
 	FullID:  "t5_2rc7j",
 	Created: &Timestamp{time.Date(2009, 11, 11, 0, 54, 28, 0, time.UTC)},
 
-	URL:          "/r/golang/",
-	Name:         "golang",
-	NamePrefixed: "r/golang",
-	Title:        "The Go Programming Language",
-	Description:  "Ask questions and post articles about the Go programming language and related tools, events etc.",
-	Type:         "public",
+	URL:             "/r/golang/",
+	Name:            "golang",
+	NamePrefixed:    "r/golang",
+	Title:           "The Go Programming Language",
+	Description:     "Ask questions and post articles about the Go programming language and related tools, events etc.",
+	DescriptionHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;Ask questions and post articles about the Go programming language and related tools, events etc.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+	SidebarMD:       "Please follow the [Go Community Code of Conduct](https://golang.org/conduct) while posting here. In short:\n\n* Treat everyone with respect and kindness.\n* Be thoughtful in how you communicate.\n* Don’t be destructive or inflammatory.\n* If you encounter an issue, please contact the moderators.\n\n**Documentation**\n\n* [Official Go Documentation](http://golang.org/doc/)\n* [Standard Library Docs](http://golang.org/pkg/)\n* [Other Package Docs](http://godoc.org/)\n\n**Community**\n\n* [Go Nuts Mailing List](http://groups.google.com/group/golang-nuts)\n* [Go questions in Stackoverflow](http://stackoverflow.com/questions/tagged/go)\n* #go-nuts in irc.freenode.org\n* [Resources for new Go programmers](http://dave.cheney.net/resources-for-new-go-programmers)\n\n**Other Resources**\n\n* [Go for App Engine](https://developers.google.com/appengine/docs/go/)!",
+	SidebarHTML:     "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;Please follow the &lt;a href=\"https://golang.org/conduct\"&gt;Go Community Code of Conduct&lt;/a&gt; while posting here. In short:&lt;/p&gt;\n\n&lt;ul&gt;\n&lt;li&gt;Treat everyone with respect and kindness.&lt;/li&gt;\n&lt;li&gt;Be thoughtful in how you communicate.&lt;/li&gt;\n&lt;li&gt;Don’t be destructive or inflammatory.&lt;/li&gt;\n&lt;li&gt;If you encounter an issue, please contact the moderators.&lt;/li&gt;\n&lt;/ul&gt;\n\n&lt;p&gt;&lt;strong&gt;Documentation&lt;/strong&gt;&lt;/p&gt;\n\n&lt;ul&gt;\n&lt;li&gt;&lt;a href=\"http://golang.org/doc/\"&gt;Official Go Documentation&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://golang.org/pkg/\"&gt;Standard Library Docs&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://godoc.org/\"&gt;Other Package Docs&lt;/a&gt;&lt;/li&gt;\n&lt;/ul&gt;\n\n&lt;p&gt;&lt;strong&gt;Community&lt;/strong&gt;&lt;/p&gt;\n\n&lt;ul&gt;\n&lt;li&gt;&lt;a href=\"http://groups.google.com/group/golang-nuts\"&gt;Go Nuts Mailing List&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://stackoverflow.com/questions/tagged/go\"&gt;Go questions in Stackoverflow&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;#go-nuts in irc.freenode.org&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://dave.cheney.net/resources-for-new-go-programmers\"&gt;Resources for new Go programmers&lt;/a&gt;&lt;/li&gt;\n&lt;/ul&gt;\n\n&lt;p&gt;&lt;strong&gt;Other Resources&lt;/strong&gt;&lt;/p&gt;\n\n&lt;ul&gt;\n&lt;li&gt;&lt;a href=\"https://developers.google.com/appengine/docs/go/\"&gt;Go for App Engine&lt;/a&gt;!&lt;/li&gt;\n&lt;/ul&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+	Type:            "public",
 
 	Subscribers:     116532,
 	ActiveUserCount: Int(386),
@@ -97,6 +128,8 @@ var expectedSubreddits = []*Subreddit{
 		Name:         "Home",
 		NamePrefixed: "r/Home",
 		Title:        "Home",
+		SidebarMD:    "Everything home related: interior design, home improvement, architecture.\n\n**Related subreddits**\n--------------------------\n* [/r/InteriorDesign](http://www.reddit.com/r/interiordesign)\n* [/r/architecture](http://www.reddit.com/r/architecture)\n* [/r/houseporn](http://www.reddit.com/r/houseporn)\n* [/r/roomporn](http://www.reddit.com/r/roomporn)\n* [/r/designmyroom](http://www.reddit.com/r/designmyroom)",
+		SidebarHTML:  "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;Everything home related: interior design, home improvement, architecture.&lt;/p&gt;\n\n&lt;h2&gt;&lt;strong&gt;Related subreddits&lt;/strong&gt;&lt;/h2&gt;\n\n&lt;ul&gt;\n&lt;li&gt;&lt;a href=\"http://www.reddit.com/r/interiordesign\"&gt;/r/InteriorDesign&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://www.reddit.com/r/architecture\"&gt;/r/architecture&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://www.reddit.com/r/houseporn\"&gt;/r/houseporn&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://www.reddit.com/r/roomporn\"&gt;/r/roomporn&lt;/a&gt;&lt;/li&gt;\n&lt;li&gt;&lt;a href=\"http://www.reddit.com/r/designmyroom\"&gt;/r/designmyroom&lt;/a&gt;&lt;/li&gt;\n&lt;/ul&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
 		Type:         "public",
 
 		Subscribers: 15336,
@@ -110,12 +143,15 @@ var expectedSubreddits = []*Subreddit{
 		FullID:  "t5_2qh1i",
 		Created: &Timestamp{time.Date(2008, 1, 25, 3, 52, 15, 0, time.UTC)},
 
-		URL:          "/r/AskReddit/",
-		Name:         "AskReddit",
-		NamePrefixed: "r/AskReddit",
-		Title:        "Ask Reddit...",
-		Description:  "r/AskReddit is the place to ask and answer thought-provoking questions.",
-		Type:         "public",
+		URL:             "/r/AskReddit/",
+		Name:            "AskReddit",
+		NamePrefixed:    "r/AskReddit",
+		Title:           "Ask Reddit...",
+		Description:     "r/AskReddit is the place to ask and answer thought-provoking questions.",
+		DescriptionHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;&lt;a href=\"/r/AskReddit\"&gt;r/AskReddit&lt;/a&gt; is the place to ask and answer thought-provoking questions.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		SidebarMD:       "###### [ [ SERIOUS ] ](http://www.reddit.com/r/askreddit/submit?selftext=true&amp;title=%5BSerious%5D)\n\n\n##### [Rules](https://www.reddit.com/r/AskReddit/wiki/index#wiki_rules):\n1. You must post a clear and direct question in the title. The title may contain two, short, necessary context sentences.\nNo text is allowed in the textbox. Your thoughts/responses to the question can go in the comments section. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_1-)\n\n2. Any post asking for advice should be generic and not specific to your situation alone. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_2-)\n\n3. Askreddit is for open-ended discussion questions. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_3-)\n\n4. Posting, or seeking, any identifying personal information, real or fake, will result in a ban without a prior warning. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_4-)\n\n5. Askreddit is not your soapbox, personal army, or advertising platform. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_5-)\n\n6. [Serious] tagged posts are off-limits to jokes or irrelevant replies. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_6-)\n\n7. Soliciting money, goods, services, or favours is not allowed. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_7-)\n\n8. Mods reserve the right to remove content or restrict users' posting privileges as necessary if it is deemed detrimental to the subreddit or to the experience of others. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_8-)\n\n9. Comment replies consisting solely of images will be removed. [more &gt;&gt;](https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_9-)\n\n##### If you think your post has disappeared, see spam or an inappropriate post, please do not hesitate to [contact the mods](https://www.reddit.com/message/compose?to=%2Fr%2FAskReddit), we're happy to help.\n\n---\n\n#### Tags to use:\n\n&gt; ## [[Serious]](https://www.reddit.com/r/AskReddit/wiki/mod_announcements#wiki_.5Bserious.5D_post_tags)\n\n### Use a **[Serious]** post tag to designate your post as a serious, on-topic-only thread.\n\n-\n\n#### Filter posts by subject:\n\n[Mod posts](http://ud.reddit.com/r/AskReddit/#ud)\n[Serious posts](http://dg.reddit.com/r/AskReddit/#dg)\n[Megathread](http://bu.reddit.com/r/AskReddit/#bu)\n[Breaking news](http://nr.reddit.com/r/AskReddit/#nr)\n[Unfilter](/r/AskReddit)\n\n\n-\n\n### Please use spoiler tags to hide spoilers. `&gt;!insert spoiler here!&lt;`\n\n-\n\n#### Other subreddits you might like:\nsome|header\n:---|:---\n[Ask Others](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_ask_others)|[Self &amp; Others](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_self_.26amp.3B_others)\n[Find a subreddit](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_find_a_subreddit)|[Learn something](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_learn_something)\n[Meta Subs](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_meta)|[What is this ___](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_what_is_this______)\n[AskReddit Offshoots](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_askreddit_offshoots)|[Offers &amp; Assistance](https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_offers_.26amp.3B_assistance)\n\n\n-\n\n### Ever read the reddiquette? [Take a peek!](/wiki/reddiquette)\n\n[](#/RES_SR_Config/NightModeCompatible)",
+		SidebarHTML:     "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;h6&gt;&lt;a href=\"http://www.reddit.com/r/askreddit/submit?selftext=true&amp;amp;title=%5BSerious%5D\"&gt; [ SERIOUS ] &lt;/a&gt;&lt;/h6&gt;\n\n&lt;h5&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_rules\"&gt;Rules&lt;/a&gt;:&lt;/h5&gt;\n\n&lt;ol&gt;\n&lt;li&gt;&lt;p&gt;You must post a clear and direct question in the title. The title may contain two, short, necessary context sentences.\nNo text is allowed in the textbox. Your thoughts/responses to the question can go in the comments section. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_1-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Any post asking for advice should be generic and not specific to your situation alone. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_2-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Askreddit is for open-ended discussion questions. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_3-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Posting, or seeking, any identifying personal information, real or fake, will result in a ban without a prior warning. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_4-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Askreddit is not your soapbox, personal army, or advertising platform. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_5-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;[Serious] tagged posts are off-limits to jokes or irrelevant replies. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_6-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Soliciting money, goods, services, or favours is not allowed. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_7-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Mods reserve the right to remove content or restrict users&amp;#39; posting privileges as necessary if it is deemed detrimental to the subreddit or to the experience of others. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_8-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;Comment replies consisting solely of images will be removed. &lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/index#wiki_-rule_9-\"&gt;more &amp;gt;&amp;gt;&lt;/a&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;/ol&gt;\n\n&lt;h5&gt;If you think your post has disappeared, see spam or an inappropriate post, please do not hesitate to &lt;a href=\"https://www.reddit.com/message/compose?to=%2Fr%2FAskReddit\"&gt;contact the mods&lt;/a&gt;, we&amp;#39;re happy to help.&lt;/h5&gt;\n\n&lt;hr/&gt;\n\n&lt;h4&gt;Tags to use:&lt;/h4&gt;\n\n&lt;blockquote&gt;\n&lt;h2&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/mod_announcements#wiki_.5Bserious.5D_post_tags\"&gt;[Serious]&lt;/a&gt;&lt;/h2&gt;\n&lt;/blockquote&gt;\n\n&lt;h3&gt;Use a &lt;strong&gt;[Serious]&lt;/strong&gt; post tag to designate your post as a serious, on-topic-only thread.&lt;/h3&gt;\n\n&lt;h2&gt;&lt;/h2&gt;\n\n&lt;h4&gt;Filter posts by subject:&lt;/h4&gt;\n\n&lt;p&gt;&lt;a href=\"http://ud.reddit.com/r/AskReddit/#ud\"&gt;Mod posts&lt;/a&gt;\n&lt;a href=\"http://dg.reddit.com/r/AskReddit/#dg\"&gt;Serious posts&lt;/a&gt;\n&lt;a href=\"http://bu.reddit.com/r/AskReddit/#bu\"&gt;Megathread&lt;/a&gt;\n&lt;a href=\"http://nr.reddit.com/r/AskReddit/#nr\"&gt;Breaking news&lt;/a&gt;\n&lt;a href=\"/r/AskReddit\"&gt;Unfilter&lt;/a&gt;&lt;/p&gt;\n\n&lt;h2&gt;&lt;/h2&gt;\n\n&lt;h3&gt;Please use spoiler tags to hide spoilers. &lt;code&gt;&amp;gt;!insert spoiler here!&amp;lt;&lt;/code&gt;&lt;/h3&gt;\n\n&lt;h2&gt;&lt;/h2&gt;\n\n&lt;h4&gt;Other subreddits you might like:&lt;/h4&gt;\n\n&lt;table&gt;&lt;thead&gt;\n&lt;tr&gt;\n&lt;th align=\"left\"&gt;some&lt;/th&gt;\n&lt;th align=\"left\"&gt;header&lt;/th&gt;\n&lt;/tr&gt;\n&lt;/thead&gt;&lt;tbody&gt;\n&lt;tr&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_ask_others\"&gt;Ask Others&lt;/a&gt;&lt;/td&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_self_.26amp.3B_others\"&gt;Self &amp;amp; Others&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_find_a_subreddit\"&gt;Find a subreddit&lt;/a&gt;&lt;/td&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_learn_something\"&gt;Learn something&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_meta\"&gt;Meta Subs&lt;/a&gt;&lt;/td&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_what_is_this______\"&gt;What is this ___&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_askreddit_offshoots\"&gt;AskReddit Offshoots&lt;/a&gt;&lt;/td&gt;\n&lt;td align=\"left\"&gt;&lt;a href=\"https://www.reddit.com/r/AskReddit/wiki/sidebarsubs#wiki_offers_.26amp.3B_assistance\"&gt;Offers &amp;amp; Assistance&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;/tbody&gt;&lt;/table&gt;\n\n&lt;h2&gt;&lt;/h2&gt;\n\n&lt;h3&gt;Ever read the reddiquette? &lt;a href=\"/wiki/reddiquette\"&gt;Take a peek!&lt;/a&gt;&lt;/h3&gt;\n\n&lt;p&gt;&lt;a href=\"#/RES_SR_Config/NightModeCompatible\"&gt;&lt;/a&gt;&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		Type:            "public",
 
 		Subscribers: 28449174,
 		NSFW:        false,
@@ -128,12 +164,15 @@ var expectedSubreddits = []*Subreddit{
 		FullID:  "t5_2qh0u",
 		Created: &Timestamp{time.Date(2008, 1, 25, 0, 31, 9, 0, time.UTC)},
 
-		URL:          "/r/pics/",
-		Name:         "pics",
-		NamePrefixed: "r/pics",
-		Title:        "Reddit Pics",
-		Description:  "A place for pictures and photographs.",
-		Type:         "public",
+		URL:             "/r/pics/",
+		Name:            "pics",
+		NamePrefixed:    "r/pics",
+		Title:           "Reddit Pics",
+		Description:     "A place for pictures and photographs.",
+		DescriptionHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;A place for pictures and photographs.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		SidebarMD:       "A place to share photographs and pictures. Feel free to post your own, but please **read the rules first** (see below), and note that we are *not a catch-all* for ALL images (of screenshots, comics, etc.).\n\n---\n\n#Spoiler code#\n\nPlease mark spoilers like this:  \n`&gt;!text here!&lt;`\n\nClick/tap to &gt;!read!&lt;.\n\n---\nCheck out http://nt.reddit.com/r/pics!\n\nCheck out /r/pics/wiki/v2/resources/takedown for help with taking down posts due to copyright or personal identifiable information reasons. \n\n---\n#[Posting Rules](/r/pics/wiki/index)#\n\n1. (1A) **No screenshots or pics where the only focus is a screen.**\n\n (1B) No pictures with added or superimposed **digital text, emojis, and \"MS Paint\"-like scribbles.** Exceptions to this rule include watermarks serving to credit the original author, and blurring/boxing out of personal information. \"Photoshopped\" or otherwise manipulated images are allowed.\n\n1. **No porn or gore.** Artistic nudity is allowed. NSFW comments must be tagged. Posting gratuitous materials may result in an immediate and permanent ban.\n\n1. **No personal information, in posts or comments.** No direct links to any Social Media. No subreddit-related meta-drama or witch-hunts. No Missing/Found posts for people or property.  A license plate is not PI. [**Reddit Policy**](https://www.reddithelp.com/en/categories/rules-reporting/account-and-community-restrictions/posting-someones-private-or-personal) \n\n **Stalking, harassment, witch hunting, or doxxing** will not be tolerated and will result in a ban.\n\n **No subreddit-related meta-drama or witch-hunts.**\n\n1. **Titles must follow all [title guidelines](https://www.reddit.com/r/pics/wiki/titles).**\n\n1. **Submissions must link directly to a specific image file or to an image hosting website with minimal ads.** *We do not allow blog hosting of images (\"blogspam\"), but links to albums on image hosting websites are okay. URL shorteners are prohibited. URLs in image or album descriptions are prohibited.* \n\n1. **No animated images.** *Please submit them to /r/gif, /r/gifs, or /r/reactiongifs instead.*\n\n1. We enforce a standard of common decency and civility here. **Please be respectful to others.** Personal attacks, bigotry, fighting words, otherwise inappropriate behavior or content, comments that insult or demean a specific user or group of users will be removed. Regular or egregious violations will result in a ban.\n**Optimally**, the level of discourse here should be at the level you'd find between you and your teacher, or between you and professional colleagues.  Obviously we're going to allow various types of humor here, but if it would make someone you respect lose respect for you, then you're best off avoiding it.\n\n1.  **No submissions featuring before-and-after depictions of personal health progress or achievement. Standalone images of medals, tokens, certificates, and awards are similarly disallowed, save for when the items are being presented as historical curiosities.**\n\n\n1. **No false claims of ownership (FCoO) or flooding.** False claims of ownership (FCoO) and/or flooding (*more than four posts in twenty-four hours*) will result in a ban.\n\n\n1. **Reposts of images on the front page, or within the set limit of /r/pics/top, will be removed.** \n\n (10A) Reposts of images currently on the front page of /r/Pics will be removed.\n\n (10B) Reposts of the top 25 images this year, and top 50 of \"all time\" will be removed.\n\n1. **Only one self-promotional link per post.** Content creators are only allowed one link per post. Anything more may result in temporary or permanent bans. Accounts that exist solely to advertise or promote will be banned.\n\n---\n\n**Loose-ends**\n\n* Serial reposters may be filtered or banned. \n\n---\n\nIf you come across any rule violations please report the submission or  [message the mods](http://www.reddit.com/message/compose?to=%23pics) and one of us will remove it!\n\n  \nIf your submission appears to be filtered, but **definitely** meets the above rules, [please send us a message](/message/compose?to=%23pics) with a link to the **comments section** of your post (not a direct link to the image). **Don't delete it**  as that just makes the filter hate you! \n\n---\n\n\n#Links#\nIf your post doesn't meet the above rules, consider submitting it on one of these other subreddits:\n\n#Subreddits\nBelow is a table of subreddits that you might want to check out!\n\nScreenshots | Advice Animals\n-----------|--------------\n/r/images | /r/adviceanimals\n/r/screenshots | /r/memes\n/r/desktops | /r/memesIRL\n/r/amoledbackgrounds | /r/wholesomememes \n**Animals** | **More Animals**\n/r/aww | /r/fawns\n/r/dogs | /r/rabbits\n/r/cats | /r/RealLifePokemon\n/r/foxes | /r/BeforeNAfterAdoption\n**GIFS** | **HQ / Curated**\n/r/gifs | /r/pic\n/r/catgifs | /r/earthporn\n/r/reactiongifs | /r/spaceporn\n\n##Topic subreddits\n\nEvery now and then, we choose 2 new topics, and find some subreddits about that topic to feature!\n\nOne Word | Art\n-----|----------\n/r/catsstandingup | /r/Art\n/r/nocontextpics | /r/ImaginaryBestOf\n&amp;nbsp; | /r/IDAP",
+		SidebarHTML:     "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;A place to share photographs and pictures. Feel free to post your own, but please &lt;strong&gt;read the rules first&lt;/strong&gt; (see below), and note that we are &lt;em&gt;not a catch-all&lt;/em&gt; for ALL images (of screenshots, comics, etc.).&lt;/p&gt;\n\n&lt;hr/&gt;\n\n&lt;h1&gt;Spoiler code&lt;/h1&gt;\n\n&lt;p&gt;Please mark spoilers like this:&lt;br/&gt;\n&lt;code&gt;&amp;gt;!text here!&amp;lt;&lt;/code&gt;&lt;/p&gt;\n\n&lt;p&gt;Click/tap to &lt;span class=\"md-spoiler-text\"&gt;read&lt;/span&gt;.&lt;/p&gt;\n\n&lt;hr/&gt;\n\n&lt;p&gt;Check out &lt;a href=\"http://nt.reddit.com/r/pics\"&gt;http://nt.reddit.com/r/pics&lt;/a&gt;!&lt;/p&gt;\n\n&lt;p&gt;Check out &lt;a href=\"/r/pics/wiki/v2/resources/takedown\"&gt;/r/pics/wiki/v2/resources/takedown&lt;/a&gt; for help with taking down posts due to copyright or personal identifiable information reasons. &lt;/p&gt;\n\n&lt;hr/&gt;\n\n&lt;h1&gt;&lt;a href=\"/r/pics/wiki/index\"&gt;Posting Rules&lt;/a&gt;&lt;/h1&gt;\n\n&lt;ol&gt;\n&lt;li&gt;&lt;p&gt;(1A) &lt;strong&gt;No screenshots or pics where the only focus is a screen.&lt;/strong&gt;&lt;/p&gt;\n\n&lt;p&gt;(1B) No pictures with added or superimposed &lt;strong&gt;digital text, emojis, and &amp;quot;MS Paint&amp;quot;-like scribbles.&lt;/strong&gt; Exceptions to this rule include watermarks serving to credit the original author, and blurring/boxing out of personal information. &amp;quot;Photoshopped&amp;quot; or otherwise manipulated images are allowed.&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;No porn or gore.&lt;/strong&gt; Artistic nudity is allowed. NSFW comments must be tagged. Posting gratuitous materials may result in an immediate and permanent ban.&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;No personal information, in posts or comments.&lt;/strong&gt; No direct links to any Social Media. No subreddit-related meta-drama or witch-hunts. No Missing/Found posts for people or property.  A license plate is not PI. &lt;a href=\"https://www.reddithelp.com/en/categories/rules-reporting/account-and-community-restrictions/posting-someones-private-or-personal\"&gt;&lt;strong&gt;Reddit Policy&lt;/strong&gt;&lt;/a&gt; &lt;/p&gt;\n\n&lt;p&gt;&lt;strong&gt;Stalking, harassment, witch hunting, or doxxing&lt;/strong&gt; will not be tolerated and will result in a ban.&lt;/p&gt;\n\n&lt;p&gt;&lt;strong&gt;No subreddit-related meta-drama or witch-hunts.&lt;/strong&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;Titles must follow all &lt;a href=\"https://www.reddit.com/r/pics/wiki/titles\"&gt;title guidelines&lt;/a&gt;.&lt;/strong&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;Submissions must link directly to a specific image file or to an image hosting website with minimal ads.&lt;/strong&gt; &lt;em&gt;We do not allow blog hosting of images (&amp;quot;blogspam&amp;quot;), but links to albums on image hosting websites are okay. URL shorteners are prohibited. URLs in image or album descriptions are prohibited.&lt;/em&gt; &lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;No animated images.&lt;/strong&gt; &lt;em&gt;Please submit them to &lt;a href=\"/r/gif\"&gt;/r/gif&lt;/a&gt;, &lt;a href=\"/r/gifs\"&gt;/r/gifs&lt;/a&gt;, or &lt;a href=\"/r/reactiongifs\"&gt;/r/reactiongifs&lt;/a&gt; instead.&lt;/em&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;We enforce a standard of common decency and civility here. &lt;strong&gt;Please be respectful to others.&lt;/strong&gt; Personal attacks, bigotry, fighting words, otherwise inappropriate behavior or content, comments that insult or demean a specific user or group of users will be removed. Regular or egregious violations will result in a ban.\n&lt;strong&gt;Optimally&lt;/strong&gt;, the level of discourse here should be at the level you&amp;#39;d find between you and your teacher, or between you and professional colleagues.  Obviously we&amp;#39;re going to allow various types of humor here, but if it would make someone you respect lose respect for you, then you&amp;#39;re best off avoiding it.&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;No submissions featuring before-and-after depictions of personal health progress or achievement. Standalone images of medals, tokens, certificates, and awards are similarly disallowed, save for when the items are being presented as historical curiosities.&lt;/strong&gt;&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;No false claims of ownership (FCoO) or flooding.&lt;/strong&gt; False claims of ownership (FCoO) and/or flooding (&lt;em&gt;more than four posts in twenty-four hours&lt;/em&gt;) will result in a ban.&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;Reposts of images on the front page, or within the set limit of &lt;a href=\"/r/pics/top\"&gt;/r/pics/top&lt;/a&gt;, will be removed.&lt;/strong&gt; &lt;/p&gt;\n\n&lt;p&gt;(10A) Reposts of images currently on the front page of &lt;a href=\"/r/Pics\"&gt;/r/Pics&lt;/a&gt; will be removed.&lt;/p&gt;\n\n&lt;p&gt;(10B) Reposts of the top 25 images this year, and top 50 of &amp;quot;all time&amp;quot; will be removed.&lt;/p&gt;&lt;/li&gt;\n&lt;li&gt;&lt;p&gt;&lt;strong&gt;Only one self-promotional link per post.&lt;/strong&gt; Content creators are only allowed one link per post. Anything more may result in temporary or permanent bans. Accounts that exist solely to advertise or promote will be banned.&lt;/p&gt;&lt;/li&gt;\n&lt;/ol&gt;\n\n&lt;hr/&gt;\n\n&lt;p&gt;&lt;strong&gt;Loose-ends&lt;/strong&gt;&lt;/p&gt;\n\n&lt;ul&gt;\n&lt;li&gt;Serial reposters may be filtered or banned. &lt;/li&gt;\n&lt;/ul&gt;\n\n&lt;hr/&gt;\n\n&lt;p&gt;If you come across any rule violations please report the submission or  &lt;a href=\"http://www.reddit.com/message/compose?to=%23pics\"&gt;message the mods&lt;/a&gt; and one of us will remove it!&lt;/p&gt;\n\n&lt;p&gt;If your submission appears to be filtered, but &lt;strong&gt;definitely&lt;/strong&gt; meets the above rules, &lt;a href=\"/message/compose?to=%23pics\"&gt;please send us a message&lt;/a&gt; with a link to the &lt;strong&gt;comments section&lt;/strong&gt; of your post (not a direct link to the image). &lt;strong&gt;Don&amp;#39;t delete it&lt;/strong&gt;  as that just makes the filter hate you! &lt;/p&gt;\n\n&lt;hr/&gt;\n\n&lt;h1&gt;Links&lt;/h1&gt;\n\n&lt;p&gt;If your post doesn&amp;#39;t meet the above rules, consider submitting it on one of these other subreddits:&lt;/p&gt;\n\n&lt;h1&gt;Subreddits&lt;/h1&gt;\n\n&lt;p&gt;Below is a table of subreddits that you might want to check out!&lt;/p&gt;\n\n&lt;table&gt;&lt;thead&gt;\n&lt;tr&gt;\n&lt;th&gt;Screenshots&lt;/th&gt;\n&lt;th&gt;Advice Animals&lt;/th&gt;\n&lt;/tr&gt;\n&lt;/thead&gt;&lt;tbody&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/images\"&gt;/r/images&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/adviceanimals\"&gt;/r/adviceanimals&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/screenshots\"&gt;/r/screenshots&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/memes\"&gt;/r/memes&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/desktops\"&gt;/r/desktops&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/memesIRL\"&gt;/r/memesIRL&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/amoledbackgrounds\"&gt;/r/amoledbackgrounds&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/wholesomememes\"&gt;/r/wholesomememes&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;strong&gt;Animals&lt;/strong&gt;&lt;/td&gt;\n&lt;td&gt;&lt;strong&gt;More Animals&lt;/strong&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/aww\"&gt;/r/aww&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/fawns\"&gt;/r/fawns&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/dogs\"&gt;/r/dogs&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/rabbits\"&gt;/r/rabbits&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/cats\"&gt;/r/cats&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/RealLifePokemon\"&gt;/r/RealLifePokemon&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/foxes\"&gt;/r/foxes&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/BeforeNAfterAdoption\"&gt;/r/BeforeNAfterAdoption&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;strong&gt;GIFS&lt;/strong&gt;&lt;/td&gt;\n&lt;td&gt;&lt;strong&gt;HQ / Curated&lt;/strong&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/gifs\"&gt;/r/gifs&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/pic\"&gt;/r/pic&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/catgifs\"&gt;/r/catgifs&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/earthporn\"&gt;/r/earthporn&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/reactiongifs\"&gt;/r/reactiongifs&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/spaceporn\"&gt;/r/spaceporn&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;/tbody&gt;&lt;/table&gt;\n\n&lt;h2&gt;Topic subreddits&lt;/h2&gt;\n\n&lt;p&gt;Every now and then, we choose 2 new topics, and find some subreddits about that topic to feature!&lt;/p&gt;\n\n&lt;table&gt;&lt;thead&gt;\n&lt;tr&gt;\n&lt;th&gt;One Word&lt;/th&gt;\n&lt;th&gt;Art&lt;/th&gt;\n&lt;/tr&gt;\n&lt;/thead&gt;&lt;tbody&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/catsstandingup\"&gt;/r/catsstandingup&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/Art\"&gt;/r/Art&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&lt;a href=\"/r/nocontextpics\"&gt;/r/nocontextpics&lt;/a&gt;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/ImaginaryBestOf\"&gt;/r/ImaginaryBestOf&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;tr&gt;\n&lt;td&gt;&amp;nbsp;&lt;/td&gt;\n&lt;td&gt;&lt;a href=\"/r/IDAP\"&gt;/r/IDAP&lt;/a&gt;&lt;/td&gt;\n&lt;/tr&gt;\n&lt;/tbody&gt;&lt;/table&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		Type:            "public",
 
 		Subscribers: 24987753,
 		NSFW:        false,
@@ -176,6 +215,155 @@ var expectedSearchPosts = []*Post{
 
 		Author:   "chocolat_ice_cream",
 		AuthorID: "t2_3p32m02",
+
+		Thumbnail: "https://a.thumbs.redditmedia.com/mTY7zZSrlStun4i_rAehBJN556LUwky1PUbIQhrVvC8.jpg",
+		Preview: &Preview{
+			Images: []*PreviewImage{
+				{
+					ID:     "6MEEtWN_cm1lRDpu_daXxHcau23YIWh0FeiB96IPgJs",
+					Source: &PreviewSource{URL: "https://external-preview.redd.it/OcR_yQzvFMo4upwEVJe0naWpvA3cmyBpucsJF2OvhLA.png?format=pjpg&amp;auto=webp&amp;s=dbe1004d6df4fb6014d78e0c0d817c1106f1f3b2", Width: 360, Height: 360},
+					Resolutions: []*PreviewSource{
+						{URL: "https://external-preview.redd.it/OcR_yQzvFMo4upwEVJe0naWpvA3cmyBpucsJF2OvhLA.png?width=108&amp;crop=smart&amp;format=pjpg&amp;auto=webp&amp;s=3de4a7249f291b848838f865bb592f7e51555e96", Width: 108, Height: 108},
+						{URL: "https://external-preview.redd.it/OcR_yQzvFMo4upwEVJe0naWpvA3cmyBpucsJF2OvhLA.png?width=216&amp;crop=smart&amp;format=pjpg&amp;auto=webp&amp;s=531916387899ed20e33386081b5d5c58a73be188", Width: 216, Height: 216},
+						{URL: "https://external-preview.redd.it/OcR_yQzvFMo4upwEVJe0naWpvA3cmyBpucsJF2OvhLA.png?width=320&amp;crop=smart&amp;format=pjpg&amp;auto=webp&amp;s=4d19996fba95dae7fb615cdc102d34c8bfb44e0a", Width: 320, Height: 320},
+					},
+				},
+			},
+		},
+
+		Awardings: []*Award{
+			{
+				ID:          "award_9663243a-e77f-44cf-abc6-850ead2cd18d",
+				Name:        "Bravo Grande!",
+				Description: "For an especially amazing showing.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/SnooClappingPremium_512.png",
+				CoinPrice:   75,
+				Count:       1,
+			},
+			{
+				ID:          "award_a2506925-fc82-4d6c-ae3b-b7217e09d7f0",
+				Name:        "Narwhal Salute",
+				Description: "A golden splash of respect",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/80j20o397jj41_NarwhalSalute.png",
+				CoinPrice:   30,
+				Count:       1,
+			},
+			{
+				ID:          "award_b4ff447e-05a5-42dc-9002-63568807cfe6",
+				Name:        "All-Seeing Upvote",
+				Description: "A glowing commendation for all to see",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/rg960rc47jj41_All-SeeingUpvote.png",
+				CoinPrice:   30,
+				Count:       2,
+			},
+			{
+				ID:          "gid_3",
+				Name:        "Platinum",
+				Description: "Gives the author a month of Reddit Premium, which includes %{coin_symbol}700 Coins for that month, and shows a Platinum Award.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/platinum_512.png",
+				CoinPrice:   1800,
+				Count:       2,
+			},
+			{
+				ID:          "gid_2",
+				Name:        "Gold",
+				Description: "Gives the author a week of Reddit Premium, %{coin_symbol}100 Coins to do with as they please, and shows a Gold Award.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/gold_512.png",
+				CoinPrice:   500,
+				Count:       4,
+			},
+			{
+				ID:          "award_b28d9565-4137-433d-bb65-5d4aa82ade4c",
+				Name:        "I'm Deceased",
+				Description: "Call an ambulance, I'm laughing too hard.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/2jd92wtn25g41_ImDeceased.png",
+				CoinPrice:   200,
+				Count:       3,
+			},
+			{
+				ID:          "award_88fdcafc-57a0-48db-99cc-76276bfaf28b",
+				Name:        "Press F",
+				Description: "To pay respects.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/tcofsbf92md41_PressF.png",
+				CoinPrice:   150,
+				Count:       1,
+			},
+			{
+				ID:          "award_77ba55a2-c33c-4351-ac49-807455a80148",
+				Name:        "Bless Up",
+				Description: "Prayers up for the blessed.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/trfv6ems1md41_BlessUp.png",
+				CoinPrice:   150,
+				Count:       1,
+			},
+			{
+				ID:          "gid_1",
+				Name:        "Silver",
+				Description: "Shows the Silver Award... and that's it.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/silver_512.png",
+				CoinPrice:   100,
+				Count:       1,
+			},
+			{
+				ID:          "award_7becef23-fb0b-4d62-b8a6-01d5759367cb",
+				Name:        "Faith In Humanity Restored",
+				Description: "When goodness lifts you",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/gva4vt20qc751_FaithInHumanityRestored.png",
+				CoinPrice:   70,
+				Count:       1,
+			},
+			{
+				ID:          "award_02d9ab2c-162e-4c01-8438-317a016ed3d9",
+				Name:        "Take My Energy",
+				Description: "I'm in this with you.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/898sygoknoo41_TakeMyEnergy.png",
+				CoinPrice:   50,
+				Count:       5,
+			},
+			{
+				ID:          "award_69c94eb4-d6a3-48e7-9cf2-0f39fed8b87c",
+				Name:        "Ally",
+				Description: "Listen, get educated, and get involved.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/5nswjpyy44551_Ally.png",
+				CoinPrice:   50,
+				Count:       1,
+			},
+		},
+		IsRobotIndexable: true,
+
+		IsVideo: true,
+		Media: &Media{
+			RedditVideo: &RedditVideo{
+				FallbackURL: "https://v.redd.it/ra4qnt8bt8d51/DASH_360.mp4?source=fallback",
+				HLSURL:      "https://v.redd.it/ra4qnt8bt8d51/HLSPlaylist.m3u8?a=1598576219%2CNTlmNTJhZDAyMTY4ZDAzNmM1NzAxMTYxZTNmYTk1OTJkYzI3MWEyYjNmNDdmYWU2MWY5ZjUwMzFkODA2YWY1ZQ%3D%3D&amp;v=1&amp;f=sd",
+				DASHURL:     "https://v.redd.it/ra4qnt8bt8d51/DASHPlaylist.mpd?a=1598576219%2CZjZhYTZlMTYxOTU2MjQzNTBlMmZmMjRiNDRlNDYxM2NjNjZiZjM2NzQxYTA5MTdhMGQyODBmNGJiYjYyOGFjMw%3D%3D&amp;v=1&amp;f=sd",
+				Width:       360,
+				Height:      360,
+				Duration:    230,
+			},
+		},
+		SecureMedia: &Media{
+			RedditVideo: &RedditVideo{
+				FallbackURL: "https://v.redd.it/ra4qnt8bt8d51/DASH_360.mp4?source=fallback",
+				HLSURL:      "https://v.redd.it/ra4qnt8bt8d51/HLSPlaylist.m3u8?a=1598576219%2CNTlmNTJhZDAyMTY4ZDAzNmM1NzAxMTYxZTNmYTk1OTJkYzI3MWEyYjNmNDdmYWU2MWY5ZjUwMzFkODA2YWY1ZQ%3D%3D&amp;v=1&amp;f=sd",
+				DASHURL:     "https://v.redd.it/ra4qnt8bt8d51/DASHPlaylist.mpd?a=1598576219%2CZjZhYTZlMTYxOTU2MjQzNTBlMmZmMjRiNDRlNDYxM2NjNjZiZjM2NzQxYTA5MTdhMGQyODBmNGJiYjYyOGFjMw%3D%3D&amp;v=1&amp;f=sd",
+				Width:       360,
+				Height:      360,
+				Duration:    230,
+			},
+		},
 	},
 	{
 		ID:      "hmwhd7",
@@ -199,6 +387,244 @@ var expectedSearchPosts = []*Post{
 
 		Author:   "Jeremy_Martin",
 		AuthorID: "t2_wgrkg",
+
+		Thumbnail: "default",
+		Preview: &Preview{
+			Images: []*PreviewImage{
+				{
+					ID:     "Ug52cYq0iihKhNVnhJnu_b8ThcVTp27Yjit2korgoUo",
+					Source: &PreviewSource{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?auto=webp&amp;s=bcb266e3d2f9b1b8410b8ebc1ba112461ac7c89b", Width: 1200, Height: 630},
+					Resolutions: []*PreviewSource{
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=108&amp;crop=smart&amp;auto=webp&amp;s=8cd17cff83d56ad74566088b46a5f656c4e6233b", Width: 108, Height: 56},
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=216&amp;crop=smart&amp;auto=webp&amp;s=279340e68ef64a890709218d27e805e40ef2d1d5", Width: 216, Height: 113},
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=320&amp;crop=smart&amp;auto=webp&amp;s=a57f95db845046e7d75af256fed8a2fab65dec60", Width: 320, Height: 168},
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=640&amp;crop=smart&amp;auto=webp&amp;s=6fc8a7055610d03faaa3b0f32ba521a99b5c2bdd", Width: 640, Height: 336},
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=960&amp;crop=smart&amp;auto=webp&amp;s=be77436ac80c45b2153de325008085920d8d8489", Width: 960, Height: 504},
+						{URL: "https://external-preview.redd.it/OIVJopP4J8t4KzYcr7bjitC4Xd8CVbOHdNJcyz27viw.jpg?width=1080&amp;crop=smart&amp;auto=webp&amp;s=71644306bcb0036f2d8ee5bf878e3c78f6c3012c", Width: 1080, Height: 567},
+					},
+				},
+			},
+		},
+
+		Awardings: []*Award{
+			{
+				ID:          "award_6001deaa-c9e0-4914-ab3d-7c4a16bd8617",
+				Name:        "Fireworks",
+				Description: "Bonfires and illuminations are still going strong. Happy 4th of July!",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/Fireworks_512.png",
+				CoinPrice:   100,
+				Count:       1,
+			},
+			{
+				ID:          "award_92cb6518-a71a-4217-9f8f-7ecbd7ab12ba",
+				Name:        "Take My Power",
+				Description: "Add my power to yours.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/TakeMyPower_512.png",
+				CoinPrice:   75,
+				Count:       2,
+			},
+			{
+				ID:          "award_9663243a-e77f-44cf-abc6-850ead2cd18d",
+				Name:        "Bravo Grande!",
+				Description: "For an especially amazing showing.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/SnooClappingPremium_512.png",
+				CoinPrice:   75,
+				Count:       1,
+			},
+			{
+				ID:          "award_c4b2e438-16bb-4568-88e7-7893b7662944",
+				Name:        "Wholesome Seal of Approval",
+				Description: "A glittering stamp for a feel-good thing",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/b9ks3a5k7jj41_WholesomeSealofApproval.png",
+				CoinPrice:   30,
+				Count:       1,
+			},
+			{
+				ID:          "award_b4ff447e-05a5-42dc-9002-63568807cfe6",
+				Name:        "All-Seeing Upvote",
+				Description: "A glowing commendation for all to see",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/rg960rc47jj41_All-SeeingUpvote.png",
+				CoinPrice:   30,
+				Count:       2,
+			},
+			{
+				ID:          "award_d48aad4b-286f-4a3a-bb41-ec05b3cd87cc",
+				Name:        "Yas Queen",
+				Description: "YAAAAAAAAAAASSS.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/kthj3e4h3bm41_YasQueen.png",
+				CoinPrice:   250,
+				Count:       2,
+			},
+			{
+				ID:          "gid_3",
+				Name:        "Platinum",
+				Description: "Gives the author a month of Reddit Premium, which includes %{coin_symbol}700 Coins for that month, and shows a Platinum Award.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/platinum_512.png",
+				CoinPrice:   1800,
+				Count:       1,
+			},
+			{
+				ID:          "gid_2",
+				Name:        "Gold",
+				Description: "Gives the author a week of Reddit Premium, %{coin_symbol}100 Coins to do with as they please, and shows a Gold Award.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/gold_512.png",
+				CoinPrice:   500,
+				Count:       3,
+			},
+			{
+				ID:          "award_43c43a35-15c5-4f73-91ef-fe538426435a",
+				Name:        "Bless Up (Pro)",
+				Description: "Prayers up for the blessed. Gives %{coin_symbol}100 Coins to both the author and the community.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/xe5mw55w5v541_BlessUp.png",
+				CoinPrice:   500,
+				Count:       1,
+			},
+			{
+				ID:          "award_5b39e8fd-7a58-4cbe-8ca0-bdedd5ed1f5a",
+				Name:        "Doot 🎵 Doot",
+				Description: "Sometimes you just got to dance with the doots.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/Updoot_512.png",
+				CoinPrice:   400,
+				Count:       6,
+			},
+			{
+				ID:          "award_725b427d-320b-4d02-8fb0-8bb7aa7b78aa",
+				Name:        "Updoot",
+				Description: "Sometimes you just got to doot.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/7atjjqpy1mc41_Updoot.png",
+				CoinPrice:   300,
+				Count:       1,
+			},
+			{
+				ID:          "award_d125d124-5c03-490d-af3d-d07c462003da",
+				Name:        "Stonks Rising",
+				Description: "To the MOON.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/s5edqq9abef41_StonksRising.png",
+				CoinPrice:   200,
+				Count:       2,
+			},
+			{
+				ID:          "award_b28d9565-4137-433d-bb65-5d4aa82ade4c",
+				Name:        "I'm Deceased",
+				Description: "Call an ambulance, I'm laughing too hard.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/2jd92wtn25g41_ImDeceased.png",
+				CoinPrice:   200,
+				Count:       7,
+			},
+			{
+				ID:          "award_88fdcafc-57a0-48db-99cc-76276bfaf28b",
+				Name:        "Press F",
+				Description: "To pay respects.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/tcofsbf92md41_PressF.png",
+				CoinPrice:   150,
+				Count:       4,
+			},
+			{
+				ID:          "award_5f123e3d-4f48-42f4-9c11-e98b566d5897",
+				Name:        "Wholesome",
+				Description: "When you come across a feel-good thing.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/5izbv4fn0md41_Wholesome.png",
+				CoinPrice:   125,
+				Count:       5,
+			},
+			{
+				ID:          "gid_1",
+				Name:        "Silver",
+				Description: "Shows the Silver Award... and that's it.",
+				Type:        "global",
+				IconURL:     "https://www.redditstatic.com/gold/awards/icon/silver_512.png",
+				CoinPrice:   100,
+				Count:       2,
+			},
+			{
+				ID:          "award_99d95969-6100-45b2-b00c-0ec45ae19596",
+				Name:        "Snek",
+				Description: "A smol, delicate danger noodle.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/rc5iesz2z8t41_Snek.png",
+				CoinPrice:   70,
+				Count:       1,
+			},
+			{
+				ID:          "award_7becef23-fb0b-4d62-b8a6-01d5759367cb",
+				Name:        "Faith In Humanity Restored",
+				Description: "When goodness lifts you",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/gva4vt20qc751_FaithInHumanityRestored.png",
+				CoinPrice:   70,
+				Count:       2,
+			},
+			{
+				ID:          "award_b1b44fa1-8179-4d84-a9ed-f25bb81f1c5f",
+				Name:        "Facepalm",
+				Description: "*Lowers face into palm*",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/ey2iodron2s41_Facepalm.png",
+				CoinPrice:   70,
+				Count:       3,
+			},
+			{
+				ID:          "award_02d9ab2c-162e-4c01-8438-317a016ed3d9",
+				Name:        "Take My Energy",
+				Description: "I'm in this with you.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/898sygoknoo41_TakeMyEnergy.png",
+				CoinPrice:   50,
+				Count:       2,
+			},
+			{
+				ID:          "award_fcccaa58-8f63-4d9d-9251-81033cd0daa3",
+				Name:        "Nothing To Do",
+				Description: "I've got nothing to do, and I'm trying to do nothing.",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/1snr345pm1w41_NothingToDo.png",
+				CoinPrice:   50,
+				Count:       1,
+			},
+			{
+				ID:          "award_cc091963-e271-45aa-ba23-b5150e565520",
+				Name:        "Safe &amp; Social",
+				Description: "Connecting together responsibly",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/qq73pijkm3p41_SafeSocial.png",
+				CoinPrice:   30,
+				Count:       1,
+			},
+			{
+				ID:          "award_3cf96da4-79da-4127-90ac-84545e1833dc",
+				Name:        "Home Time",
+				Description: "Staying home &amp; being safe when you can",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/qh4pzo76v9p41_HomeTime.png",
+				CoinPrice:   30,
+				Count:       2,
+			},
+			{
+				ID:          "award_a903c949-ccc5-420d-8239-1bbefc424838",
+				Name:        "Healthcare Hero",
+				Description: "Putting yourself on the line for us - you are the perfect super hero!",
+				Type:        "global",
+				IconURL:     "https://i.redd.it/award_images/t5_22cerq/xs2na1t1v9p41_HealthcareHero.png",
+				CoinPrice:   30,
+				Count:       7,
+			},
+		},
+		IsRobotIndexable: true,
 	},
 }
 
@@ -211,6 +637,7 @@ var expectedRandomSubreddit = &Subreddit{
 	NamePrefixed: "r/GalaxyS8",
 	Title:        "Samsung Galaxy S8",
 	Description:  "The only place for news, discussion, photos, and everything else Samsung Galaxy S8.",
+	SidebarMD:    "### Rules\n\n* Posts and comments must be relevant to the Galaxy S8.\n* Do not post any referral codes.\n* No trolling.\n* No buying/selling/trading.\n* Do not editorialize submission titles.\n* No spamming or blog-spam.\n* Any photos/videos taken with the S8 should be posted in the weekly photography thread.\n\n### Link flair must be used\n\n* News\n* Rumor\n* Discussion\n* Help\n* Tricks\n* Creative\n* Other\n\nFlair can also be added by putting it in brackets before the post title, for example:\n&gt; [Help] I need help\n\n### Related Subreddits\n\n* [Samsung](https://www.reddit.com/r/Samsung)\n* [Galaxy Photography](https://www.reddit.com/r/galaxyphotography)\n* [Amoled Backgrounds](https://www.reddit.com/r/Amoledbackgrounds)\n\n### Discord Server\n\n* [Click Here to Join](https://discord.gg/4uxusu8)",
 	Type:         "public",
 
 	Subscribers: 52357,
@@ -468,6 +895,125 @@ func TestSubredditService_NewPosts(t *testing.T) {
 	require.Equal(t, "t3_hyhquk", resp.After)
 }
 
+func TestSubredditService_NewPostsPaginator(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/subreddit/posts.json")
+	require.NoError(t, err)
+
+	var afters []string
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "10", r.URL.Query().Get("limit"))
+
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		if after == "" {
+			fmt.Fprint(w, blob)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [], "after": null}}`)
+	})
+
+	opts := &ListOptions{Limit: 10}
+	paginator := client.Subreddit.NewPostsPaginator("test", opts)
+
+	posts, err := paginator.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expectedPosts, posts)
+
+	posts, err = paginator.Next(ctx)
+	require.NoError(t, err)
+	require.Empty(t, posts)
+
+	_, err = paginator.Next(ctx)
+	require.Equal(t, io.EOF, err)
+
+	require.Equal(t, []string{"", "t3_hyhquk"}, afters)
+	require.Empty(t, opts.After)
+}
+
+func TestSubredditService_NewPostsPaginator_Before(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/subreddit/posts.json")
+	require.NoError(t, err)
+
+	var queries []url.Values
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if len(queries) == 1 {
+			fmt.Fprint(w, blob)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [], "after": null}}`)
+	})
+
+	opts := &ListOptions{Before: "t3_abc123"}
+	paginator := client.Subreddit.NewPostsPaginator("test", opts)
+
+	_, err = paginator.Next(ctx)
+	require.NoError(t, err)
+	_, err = paginator.Next(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	require.Equal(t, "t3_abc123", queries[0].Get("before"))
+	require.Empty(t, queries[0].Get("after"))
+	require.Empty(t, queries[1].Get("before"))
+	require.Equal(t, "t3_hyhquk", queries[1].Get("after"))
+	require.Equal(t, "t3_abc123", opts.Before)
+}
+
+func TestSubredditService_NewPostsPaginator_InvalidOptions(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+	})
+
+	paginator := client.Subreddit.NewPostsPaginator("test", &ListOptions{After: "t3_abc", Before: "t3_def"})
+	_, err := paginator.Next(ctx)
+	require.EqualError(t, err, "*ListOptions: After and Before cannot both be set")
+
+	paginator = client.Subreddit.NewPostsPaginator("test", &ListOptions{After: "abc"})
+	_, err = paginator.Next(ctx)
+	require.EqualError(t, err, `(*ListOptions).After: "abc" is not a full ID`)
+
+	require.Zero(t, counter)
+}
+
+func TestSubredditService_NewPostsPaginator_Canceled(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/subreddit/posts.json")
+	require.NoError(t, err)
+
+	var counter int
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		fmt.Fprint(w, blob)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	paginator := client.Subreddit.NewPostsPaginator("test", nil)
+
+	_, err = paginator.Next(ctx)
+	require.NoError(t, err)
+
+	cancel()
+	_, err = paginator.Next(ctx)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 1, counter)
+
+	// the page can be fetched again with another context
+	posts, err := paginator.Next(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedPosts, posts)
+	require.Equal(t, 2, counter)
+}
+
 func TestSubredditService_RisingPosts(t *testing.T) {
 	client, mux := setup(t)
 
@@ -1720,3 +2266,31 @@ func TestSubredditService_PostRequirements(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedSubredditPostRequirements, postRequirements)
 }
+
+func TestSubreddit_Sidebar(t *testing.T) {
+	subreddit := new(Subreddit)
+	err := json.Unmarshal([]byte(`{
+		"display_name": "test",
+		"public_description": "A **test** subreddit.",
+		"public_description_html": "&lt;div class=\"md\"&gt;&lt;p&gt;A &lt;strong&gt;test&lt;/strong&gt; subreddit.&lt;/p&gt;&lt;/div&gt;",
+		"description": "# Rules\n\n1. Be nice",
+		"description_html": "&lt;div class=\"md\"&gt;&lt;h1&gt;Rules&lt;/h1&gt;&lt;/div&gt;"
+	}`), subreddit)
+	require.NoError(t, err)
+	require.Equal(t, "A **test** subreddit.", subreddit.Description)
+	require.Equal(t, `&lt;div class="md"&gt;&lt;p&gt;A &lt;strong&gt;test&lt;/strong&gt; subreddit.&lt;/p&gt;&lt;/div&gt;`, subreddit.DescriptionHTML)
+	require.Equal(t, "# Rules\n\n1. Be nice", subreddit.SidebarMD)
+	require.Equal(t, `&lt;div class="md"&gt;&lt;h1&gt;Rules&lt;/h1&gt;&lt;/div&gt;`, subreddit.SidebarHTML)
+
+	subreddit = new(Subreddit)
+	err = json.Unmarshal([]byte(`{
+		"display_name": "test",
+		"public_description_html": null,
+		"description": null,
+		"description_html": null
+	}`), subreddit)
+	require.NoError(t, err)
+	require.Empty(t, subreddit.DescriptionHTML)
+	require.Empty(t, subreddit.SidebarMD)
+	require.Empty(t, subreddit.SidebarHTML)
+}
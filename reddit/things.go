@@ -1,8 +1,18 @@
 package reddit
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -33,6 +43,84 @@ type anchor interface {
 	After() string
 }
 
+var (
+	postJSONKeys    = jsonKeys(reflect.TypeOf(Post{}))
+	commentJSONKeys = jsonKeys(reflect.TypeOf(Comment{}), "depth", "collapsed_reason_code")
+)
+
+// jsonKeys returns the JSON keys that the fields of the struct type t are decoded from,
+// along with any additional keys.
+func jsonKeys(t reflect.Type, additional ...string) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys[name] = true
+	}
+	for _, key := range additional {
+		keys[key] = true
+	}
+	return keys
+}
+
+// captureExtra adds the fields of the JSON object b whose keys are not known to extra.
+func captureExtra(b []byte, known map[string]bool, extra *map[string]json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		if known[key] {
+			continue
+		}
+		if *extra == nil {
+			*extra = make(map[string]json.RawMessage)
+		}
+		(*extra)[key] = value
+	}
+
+	return nil
+}
+
+// Thing is an entity on Reddit that has a full ID, e.g. a post or a comment.
+// Use a type switch to get the underlying entity.
+type Thing interface {
+	// Kind returns the kind of the thing, e.g. t1 for comments and t3 for posts.
+	Kind() string
+}
+
+// Kind returns the kind of a comment, t1.
+func (*Comment) Kind() string { return kindComment }
+
+// Kind returns the kind of a "more" comment, more.
+func (*More) Kind() string { return kindMore }
+
+// Kind returns the kind of a private message, t4.
+func (*Message) Kind() string { return kindMessage }
+
+// Kind returns the kind of a user, t2.
+func (*User) Kind() string { return kindUser }
+
+// Kind returns the kind of a post, t3.
+func (*Post) Kind() string { return kindPost }
+
+// Kind returns the kind of a subreddit, t5.
+func (*Subreddit) Kind() string { return kindSubreddit }
+
+// Kind returns the kind of a live thread, LiveUpdateEvent.
+func (*LiveThread) Kind() string { return kindLiveThread }
+
+// Kind returns the kind of a live thread update, LiveUpdate.
+func (*LiveThreadUpdate) Kind() string { return kindLiveThreadUpdate }
+
 // thing is an entity on Reddit.
 // Its kind reprsents what it is and what is stored in the Data field.
 // e.g. t1 = comment, t2 = user, t3 = post, etc.
@@ -65,58 +153,183 @@ func (t *thing) UnmarshalJSON(b []byte) error {
 	}
 
 	t.Kind = root.Kind
-	var v interface{}
+	v := newThingData(t.Kind)
+	if v == nil {
+		return fmt.Errorf("unrecognized kind: %q", t.Kind)
+	}
+
+	err = json.Unmarshal(root.Data, v)
+	if err != nil {
+		return err
+	}
+
+	t.Data = v
+	return nil
+}
 
-	switch t.Kind {
+// newThingData returns a pointer to a new value to decode the data of a thing of the kind into,
+// or nil if the kind is not recognized.
+func newThingData(kind string) interface{} {
+	switch kind {
 	case kindListing:
-		v = new(listing)
+		return new(listing)
 	case kindComment:
-		v = new(Comment)
+		return new(Comment)
 	case kindMore:
-		v = new(More)
+		return new(More)
+	case kindMessage:
+		return new(Message)
 	case kindUser:
-		v = new(User)
+		return new(User)
 	case kindPost:
-		v = new(Post)
+		return new(Post)
 	case kindSubreddit:
-		v = new(Subreddit)
+		return new(Subreddit)
 	case kindSubredditSettings:
-		v = new(SubredditSettings)
+		return new(SubredditSettings)
 	case kindLiveThread:
-		v = new(LiveThread)
+		return new(LiveThread)
 	case kindLiveThreadUpdate:
-		v = new(LiveThreadUpdate)
+		return new(LiveThreadUpdate)
 	case kindModAction:
-		v = new(ModAction)
+		return new(ModAction)
 	case kindMulti:
-		v = new(Multi)
+		return new(Multi)
 	case kindMultiDescription:
-		v = new(rootMultiDescription)
+		return new(rootMultiDescription)
 	case kindTrophy:
-		v = new(Trophy)
+		return new(Trophy)
 	case kindTrophyList:
-		v = new(trophyList)
+		return new(trophyList)
 	case kindKarmaList:
-		v = new([]*SubredditKarma)
+		return new([]*SubredditKarma)
+	case kindUserList:
+		return new(userList)
 	case kindWikiPage:
-		v = new(WikiPage)
+		return new(WikiPage)
 	case kindWikiPageListing:
-		v = new([]string)
+		return new([]string)
 	case kindWikiPageSettings:
-		v = new(WikiPageSettings)
+		return new(WikiPageSettings)
 	case kindStyleSheet:
-		v = new(SubredditStyleSheet)
-	default:
-		return fmt.Errorf("unrecognized kind: %q", t.Kind)
+		return new(SubredditStyleSheet)
 	}
+	return nil
 
-	err = json.Unmarshal(root.Data, v)
-	if err != nil {
-		return err
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The data is encoded the way the Reddit API encodes it, which differs from what
+// json.Marshal produces for some types, e.g. the replies of a comment are a listing.
+func (t thing) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string      `json:"kind"`
+		Data interface{} `json:"data"`
+	}{t.Kind, redditJSON(t.Data)})
+}
+
+// redditJSON wraps v, if needed, so that it is encoded the way the Reddit API encodes it.
+func redditJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *Comment:
+		if v != nil {
+			return redditComment{v}
+		}
+	case *Message:
+		if v != nil {
+			return redditMessage{v}
+		}
+	case *Post:
+		if v != nil {
+			return redditPost{v}
+		}
 	}
+	return v
+}
 
-	t.Data = v
-	return nil
+// redditComment encodes a comment like the Reddit API does: its replies are a listing,
+// or "" if there are none, and its lists are present even when empty.
+type redditComment struct {
+	*Comment
+}
+
+func (c redditComment) MarshalJSON() ([]byte, error) {
+	var replies interface{} = ""
+	if len(c.Replies.Comments) > 0 || c.Replies.More != nil {
+		l := &listing{things: things{Comments: c.Replies.Comments}}
+		if c.Replies.More != nil {
+			l.things.Mores = []*More{c.Replies.More}
+		}
+		replies = thing{Kind: kindListing, Data: l}
+	}
+
+	type comment Comment
+	return json.Marshal(&struct {
+		*comment
+		Replies             interface{}    `json:"replies"`
+		AuthorFlairRichText []FlairSegment `json:"author_flair_richtext"`
+		Awardings           []*Award       `json:"all_awardings"`
+		Depth               int            `json:"depth,omitempty"`
+		CollapseReasonCode  CollapseReason `json:"collapsed_reason_code,omitempty"`
+	}{(*comment)(c.Comment), replies, c.AuthorFlairRichText, c.Awardings, c.depth, c.collapseReasonCode})
+}
+
+// redditMessage encodes a message like the Reddit API does: its replies are a listing,
+// or "" if there are none.
+type redditMessage struct {
+	*Message
+}
+
+func (m redditMessage) MarshalJSON() ([]byte, error) {
+	var replies interface{} = ""
+	if len(m.Replies) > 0 {
+		replies = thing{Kind: kindListing, Data: &listing{things: things{Messages: m.Replies}}}
+	}
+
+	type message Message
+	return json.Marshal(&struct {
+		*message
+		Replies interface{} `json:"replies"`
+	}{(*message)(m.Message), replies})
+}
+
+// redditPost encodes a post like the Reddit API does: its lists are present even when empty.
+type redditPost struct {
+	*Post
+}
+
+func (p redditPost) MarshalJSON() ([]byte, error) {
+	var crossposts []redditPost
+	for _, parent := range p.CrosspostParentList {
+		crossposts = append(crossposts, redditPost{parent})
+	}
+
+	type post Post
+	return json.Marshal(&struct {
+		*post
+		Awardings           []*Award     `json:"all_awardings"`
+		CrosspostParentList []redditPost `json:"crosspost_parent_list,omitempty"`
+	}{(*post)(p.Post), p.Awardings, crossposts})
+}
+
+// EncodeThing writes the JSON encoding of v to w, wrapped in the kind/data object used by Reddit,
+// e.g. {"kind": "t3", "data": {...}} for a post, followed by a newline.
+// The result can be decoded back by the package like any response from Reddit.
+func EncodeThing(w io.Writer, v Thing) error {
+	if v == nil {
+		return errors.New("thing: cannot be nil")
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return errors.New("thing: cannot be nil")
+	}
+
+	kind := v.Kind()
+	if newThingData(kind) == nil {
+		return fmt.Errorf("thing: unsupported kind %q", kind)
+	}
+
+	return json.NewEncoder(w).Encode(thing{Kind: kind, Data: v})
 }
 
 func (t *thing) Listing() (v *listing, ok bool) {
@@ -134,6 +347,11 @@ func (t *thing) More() (v *More, ok bool) {
 	return
 }
 
+func (t *thing) Message() (v *Message, ok bool) {
+	v, ok = t.Data.(*Message)
+	return
+}
+
 func (t *thing) User() (v *User, ok bool) {
 	v, ok = t.Data.(*User)
 	return
@@ -195,6 +413,14 @@ func (t *thing) TrophyList() ([]*Trophy, bool) {
 	return *v, ok
 }
 
+func (t *thing) UserList() ([]*Relationship, bool) {
+	v, ok := t.Data.(*userList)
+	if !ok {
+		return nil, ok
+	}
+	return *v, ok
+}
+
 func (t *thing) Karma() ([]*SubredditKarma, bool) {
 	v, ok := t.Data.(*[]*SubredditKarma)
 	if !ok {
@@ -255,6 +481,14 @@ func (l *listing) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaler interface.
+func (l listing) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Things things `json:"children"`
+		After  string `json:"after"`
+	}{l.things, l.after})
+}
+
 func (l *listing) Comments() []*Comment {
 	if l == nil {
 		return nil
@@ -318,9 +552,79 @@ func (l *listing) LiveThreadUpdates() []*LiveThreadUpdate {
 	return l.things.LiveThreadUpdates
 }
 
+// Page is a single page of a listing, holding the things it contains.
+type Page struct {
+	Comments          []*Comment
+	Mores             []*More
+	Messages          []*Message
+	Users             []*User
+	Posts             []*Post
+	Subreddits        []*Subreddit
+	ModActions        []*ModAction
+	Multis            []*Multi
+	LiveThreads       []*LiveThread
+	LiveThreadUpdates []*LiveThreadUpdate
+	Trophies          []*Trophy
+	Relationships     []*Relationship
+
+	// Anchor to get the next page of the listing, empty if this is the last page.
+	After string
+}
+
+// DecodeStrictListing decodes a listing, like the ones returned by most endpoints that return
+// multiple things. Unlike decoding into a thing, it returns an error if b is not a listing,
+// e.g. if an endpoint returned a single post instead.
+func DecodeStrictListing(b []byte) (*Page, error) {
+	root := new(thing)
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return nil, err
+	}
+
+	l, ok := root.Listing()
+	if !ok {
+		return nil, fmt.Errorf("expected kind %q, got %q", kindListing, root.Kind)
+	}
+
+	return &Page{
+		Comments:          l.things.Comments,
+		Mores:             l.things.Mores,
+		Messages:          l.things.Messages,
+		Users:             l.things.Users,
+		Posts:             l.things.Posts,
+		Subreddits:        l.things.Subreddits,
+		ModActions:        l.things.ModActions,
+		Multis:            l.things.Multis,
+		LiveThreads:       l.things.LiveThreads,
+		LiveThreadUpdates: l.things.LiveThreadUpdates,
+		Trophies:          l.things.Trophies,
+		Relationships:     l.things.Relationships,
+		After:             l.after,
+	}, nil
+}
+
+// RoundTrip decodes a listing and encodes it back to JSON, in the same format as the
+// Reddit API, e.g. to check that a cached payload survives being decoded by this package.
+// Fields that this package doesn't know about are dropped, and the things of the
+// listing are grouped by kind. Running RoundTrip on its own output returns it unchanged.
+func RoundTrip(b []byte) ([]byte, error) {
+	root := new(thing)
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.Kind != kindListing {
+		return nil, fmt.Errorf("expected kind %q, got %q", kindListing, root.Kind)
+	}
+
+	return json.Marshal(root)
+}
+
 type things struct {
 	Comments          []*Comment
 	Mores             []*More
+	Messages          []*Message
 	Users             []*User
 	Posts             []*Post
 	Subreddits        []*Subreddit
@@ -328,6 +632,8 @@ type things struct {
 	Multis            []*Multi
 	LiveThreads       []*LiveThread
 	LiveThreadUpdates []*LiveThreadUpdate
+	Trophies          []*Trophy
+	Relationships     []*Relationship
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -341,6 +647,49 @@ func (t *things) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON implements the json.Marshaler interface.
+// The things are encoded as an array, grouped by kind.
+func (t things) MarshalJSON() ([]byte, error) {
+	children := make([]thing, 0)
+	for _, v := range t.Comments {
+		children = append(children, thing{Kind: kindComment, Data: v})
+	}
+	for _, v := range t.Mores {
+		children = append(children, thing{Kind: kindMore, Data: v})
+	}
+	for _, v := range t.Messages {
+		children = append(children, thing{Kind: kindMessage, Data: v})
+	}
+	for _, v := range t.Users {
+		children = append(children, thing{Kind: kindUser, Data: v})
+	}
+	for _, v := range t.Posts {
+		children = append(children, thing{Kind: kindPost, Data: v})
+	}
+	for _, v := range t.Subreddits {
+		children = append(children, thing{Kind: kindSubreddit, Data: v})
+	}
+	for _, v := range t.ModActions {
+		children = append(children, thing{Kind: kindModAction, Data: v})
+	}
+	for _, v := range t.Multis {
+		children = append(children, thing{Kind: kindMulti, Data: v})
+	}
+	for _, v := range t.LiveThreads {
+		children = append(children, thing{Kind: kindLiveThread, Data: v})
+	}
+	for _, v := range t.LiveThreadUpdates {
+		children = append(children, thing{Kind: kindLiveThreadUpdate, Data: v})
+	}
+	for _, v := range t.Trophies {
+		children = append(children, thing{Kind: kindTrophy, Data: v})
+	}
+	if len(t.Relationships) > 0 {
+		children = append(children, thing{Kind: kindUserList, Data: userList(t.Relationships)})
+	}
+	return json.Marshal(children)
+}
+
 func (t *things) add(things ...thing) {
 	for _, thing := range things {
 		switch v := thing.Data.(type) {
@@ -348,6 +697,8 @@ func (t *things) add(things ...thing) {
 			t.Comments = append(t.Comments, v)
 		case *More:
 			t.Mores = append(t.Mores, v)
+		case *Message:
+			t.Messages = append(t.Messages, v)
 		case *User:
 			t.Users = append(t.Users, v)
 		case *Post:
@@ -362,127 +713,924 @@ func (t *things) add(things ...thing) {
 			t.LiveThreads = append(t.LiveThreads, v)
 		case *LiveThreadUpdate:
 			t.LiveThreadUpdates = append(t.LiveThreadUpdates, v)
+		case *Trophy:
+			t.Trophies = append(t.Trophies, v)
+		case *trophyList:
+			t.Trophies = append(t.Trophies, *v...)
+		case *userList:
+			t.Relationships = append(t.Relationships, *v...)
 		}
 	}
 }
 
-type trophyList []*Trophy
-
-// UnmarshalJSON implements the json.Unmarshaler interface.
-func (l *trophyList) UnmarshalJSON(b []byte) error {
-	root := new(struct {
-		Trophies []thing `json:"trophies"`
-	})
-
-	err := json.Unmarshal(b, root)
-	if err != nil {
-		return err
+// DecodeThingsPooled decodes a JSON array of things, like the children of a listing, the same way
+// as decoding into things directly, but reuses the intermediate slice of things from pool in order
+// to reduce allocations when decoding many responses.
+//
+// The pool must either be empty with a nil New function, or only hold values of type *[]thing;
+// values of any other type are ignored. The pool must not be shared with other kinds of values.
+// The returned things do not reference the pooled slice, so they stay valid after it is reused.
+func DecodeThingsPooled(b []byte, pool *sync.Pool) (things, error) {
+	buf, ok := pool.Get().(*[]thing)
+	if !ok || buf == nil {
+		buf = new([]thing)
 	}
 
-	*l = make(trophyList, 0, len(root.Trophies))
-	for _, thing := range root.Trophies {
-		if trophy, ok := thing.Trophy(); ok {
-			*l = append(*l, trophy)
+	defer func() {
+		// drop the references to the decoded data so it can be garbage collected
+		for i := range *buf {
+			(*buf)[i] = thing{}
 		}
+		*buf = (*buf)[:0]
+		pool.Put(buf)
+	}()
+
+	var t things
+	if err := json.Unmarshal(b, buf); err != nil {
+		return t, err
 	}
 
-	return nil
+	t.add(*buf...)
+	return t, nil
 }
 
-// Comment is a comment posted by a user.
-type Comment struct {
-	ID      string     `json:"id,omitempty"`
-	FullID  string     `json:"name,omitempty"`
-	Created *Timestamp `json:"created_utc,omitempty"`
-	Edited  *Timestamp `json:"edited,omitempty"`
+// DecodeThingsLimited decodes a JSON array of things, like the children of a listing, the same way
+// as decoding into things directly, but stops after the first max things, ignoring the rest of the
+// array without reading it. This bounds the work done on untrusted payloads with huge arrays.
+// Things nested in others, such as the replies of a comment, don't count towards max.
+func DecodeThingsLimited(b []byte, max int) (things, error) {
+	var t things
 
-	ParentID  string `json:"parent_id,omitempty"`
-	Permalink string `json:"permalink,omitempty"`
+	dec := json.NewDecoder(bytes.NewReader(b))
+	token, err := dec.Token()
+	if err != nil {
+		return t, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return t, fmt.Errorf("expected an array of things, got %v", token)
+	}
 
-	Body            string `json:"body,omitempty"`
-	Author          string `json:"author,omitempty"`
-	AuthorID        string `json:"author_fullname,omitempty"`
-	AuthorFlairText string `json:"author_flair_text,omitempty"`
-	AuthorFlairID   string `json:"author_flair_template_id,omitempty"`
+	for n := 0; n < max && dec.More(); n++ {
+		var v thing
+		if err := dec.Decode(&v); err != nil {
+			return t, err
+		}
+		t.add(v)
+	}
 
-	SubredditName         string `json:"subreddit,omitempty"`
-	SubredditNamePrefixed string `json:"subreddit_name_prefixed,omitempty"`
-	SubredditID           string `json:"subreddit_id,omitempty"`
+	return t, nil
+}
 
-	// Indicates if you've upvote/downvoted (true/false).
-	// If neither, it will be nil.
-	Likes *bool `json:"likes"`
+// DecodeThingsCaptureExtra decodes a JSON array of things, like the children of a listing, the same way
+// as decoding into things directly, but also keeps the fields of posts and comments that don't map
+// to any of their fields in their Extra field, including those of replies and crossposted posts.
+// This is useful to inspect fields recently added to the API, at the cost of slower decoding.
+// The decoded things are grouped by type in the fields of the result, e.g. Posts and Comments.
+func DecodeThingsCaptureExtra(b []byte) (things, error) {
+	var t things
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return t, err
+	}
 
-	Score            int `json:"score"`
-	Controversiality int `json:"controversiality"`
+	for _, raw := range raws {
+		var v thing
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return t, err
+		}
 
-	PostID string `json:"link_id,omitempty"`
-	// This doesn't appear consistently.
-	PostTitle string `json:"link_title,omitempty"`
-	// This doesn't appear consistently.
-	PostPermalink string `json:"link_permalink,omitempty"`
-	// This doesn't appear consistently.
-	PostAuthor string `json:"link_author,omitempty"`
-	// This doesn't appear consistently.
-	PostNumComments *int `json:"num_comments,omitempty"`
+		root := new(struct {
+			Data json.RawMessage `json:"data"`
+		})
+		if err := json.Unmarshal(raw, root); err != nil {
+			return t, err
+		}
 
-	IsSubmitter bool `json:"is_submitter"`
-	ScoreHidden bool `json:"score_hidden"`
-	Saved       bool `json:"saved"`
-	Stickied    bool `json:"stickied"`
-	Locked      bool `json:"locked"`
-	CanGild     bool `json:"can_gild"`
-	NSFW        bool `json:"over_18"`
+		var err error
+		switch data := v.Data.(type) {
+		case *Post:
+			err = data.captureExtraFields(root.Data)
+		case *Comment:
+			err = data.captureExtraFields(root.Data)
+		}
+		if err != nil {
+			return t, err
+		}
 
-	Replies Replies `json:"replies"`
-}
+		t.add(v)
+	}
 
-// HasMore determines whether the comment has more replies to load in its reply tree.
-func (c *Comment) HasMore() bool {
-	return c.Replies.More != nil && len(c.Replies.More.Children) > 0
+	return t, nil
 }
 
-// addCommentToReplies traverses the comment tree to find the one
-// that the 2nd comment is replying to. It then adds it to its replies.
-func (c *Comment) addCommentToReplies(comment *Comment) {
-	if c.FullID == comment.ParentID {
-		c.Replies.Comments = append(c.Replies.Comments, comment)
-		return
+// captureExtraFields sets the Extra field of the post, and of its crossposted posts,
+// from b, the JSON object the post was decoded from.
+func (p *Post) captureExtraFields(b []byte) error {
+	if err := captureExtra(b, postJSONKeys, &p.Extra); err != nil {
+		return err
 	}
 
-	for _, reply := range c.Replies.Comments {
-		reply.addCommentToReplies(comment)
+	root := new(struct {
+		CrosspostParentList []json.RawMessage `json:"crosspost_parent_list"`
+	})
+	if err := json.Unmarshal(b, root); err != nil {
+		return err
 	}
-}
 
-func (c *Comment) addMoreToReplies(more *More) {
-	if c.FullID == more.ParentID {
-		c.Replies.More = more
-		return
+	// parents deeper than MaxCrosspostDepth weren't decoded
+	for i, parent := range p.CrosspostParentList {
+		if i >= len(root.CrosspostParentList) {
+			break
+		}
+		if err := parent.captureExtraFields(root.CrosspostParentList[i]); err != nil {
+			return err
+		}
 	}
 
-	for _, reply := range c.Replies.Comments {
-		reply.addMoreToReplies(more)
-	}
+	return nil
 }
 
-// Replies holds replies to a comment.
-// It contains both comments and "more" comments, which are entrypoints to other
-// comments that were left out.
-type Replies struct {
-	Comments []*Comment `json:"comments,omitempty"`
-	More     *More      `json:"-"`
-}
+// captureExtraFields sets the Extra field of the comment, and of its replies,
+// from b, the JSON object the comment was decoded from.
+func (c *Comment) captureExtraFields(b []byte) error {
+	if err := captureExtra(b, commentJSONKeys, &c.Extra); err != nil {
+		return err
+	}
+
+	root := new(struct {
+		Replies json.RawMessage `json:"replies"`
+	})
+	if err := json.Unmarshal(b, root); err != nil {
+		return err
+	}
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
-func (r *Replies) UnmarshalJSON(data []byte) error {
 	// if a comment has no replies, its "replies" field is set to ""
-	if string(data) == `""` {
-		r = nil
+	if len(c.Replies.Comments) == 0 || len(root.Replies) == 0 || root.Replies[0] != '{' {
 		return nil
 	}
 
-	root := new(thing)
+	replies := new(struct {
+		Data struct {
+			Children []struct {
+				Kind string          `json:"kind"`
+				Data json.RawMessage `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	})
+	if err := json.Unmarshal(root.Replies, replies); err != nil {
+		return err
+	}
+
+	// the replies were decoded in the order of the listing
+	i := 0
+	for _, child := range replies.Data.Children {
+		if child.Kind != kindComment {
+			continue
+		}
+		if i >= len(c.Replies.Comments) {
+			break
+		}
+		if err := c.Replies.Comments[i].captureExtraFields(child.Data); err != nil {
+			return err
+		}
+		i++
+	}
+
+	return nil
+}
+
+// NormalizeFullIDs sets the full ID of posts and comments that are missing one from their ID,
+// e.g. abc123 becomes t3_abc123 for a post. Some payloads, like archived ones, don't include it.
+func (t *things) NormalizeFullIDs() {
+	for _, post := range t.Posts {
+		if post.FullID == "" && post.ID != "" {
+			post.FullID = kindPost + "_" + post.ID
+		}
+	}
+	for _, comment := range t.Comments {
+		if comment.FullID == "" && comment.ID != "" {
+			comment.FullID = kindComment + "_" + comment.ID
+		}
+	}
+}
+
+// Find returns the thing with the given full ID, e.g. t3_abc123.
+// Users don't have a full ID of their own, so they are matched with t2_ followed by their ID.
+func (t things) Find(fullID string) (Thing, bool) {
+	for _, v := range t.Comments {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.Mores {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.Messages {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.Users {
+		if kindUser+"_"+v.ID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.Posts {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.Subreddits {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.LiveThreads {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	for _, v := range t.LiveThreadUpdates {
+		if v.FullID == fullID {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// CreatedRange returns the creation times of the earliest and latest created things,
+// e.g. to label the time span of a page of results.
+// Things without a creation time are ignored. If none has one, both times are zero.
+func (t things) CreatedRange() (earliest, latest time.Time) {
+	var timestamps []*Timestamp
+	for _, v := range t.Comments {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.Messages {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.Users {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.Posts {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.Subreddits {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.ModActions {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.Multis {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.LiveThreads {
+		timestamps = append(timestamps, v.Created)
+	}
+	for _, v := range t.LiveThreadUpdates {
+		timestamps = append(timestamps, v.Created)
+	}
+
+	for _, ts := range timestamps {
+		if ts == nil || ts.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || ts.Before(earliest) {
+			earliest = ts.Time
+		}
+		if latest.IsZero() || ts.After(latest) {
+			latest = ts.Time
+		}
+	}
+
+	return earliest, latest
+}
+
+// PartitionNSFW splits the things into those that are safe for work and those that aren't,
+// based on the NSFW flag of posts and comments. Other kinds of things are kept with the SFW ones.
+func (t things) PartitionNSFW() (sfw things, nsfw things) {
+	sfw = t
+	sfw.Posts, sfw.Comments = nil, nil
+
+	for _, post := range t.Posts {
+		if post.NSFW {
+			nsfw.Posts = append(nsfw.Posts, post)
+		} else {
+			sfw.Posts = append(sfw.Posts, post)
+		}
+	}
+
+	for _, comment := range t.Comments {
+		if comment.NSFW {
+			nsfw.Comments = append(nsfw.Comments, comment)
+		} else {
+			sfw.Comments = append(sfw.Comments, comment)
+		}
+	}
+
+	return sfw, nsfw
+}
+
+// FilterPosts returns the posts for which pred returns true.
+func (t things) FilterPosts(pred func(*Post) bool) []*Post {
+	var posts []*Post
+	for _, post := range t.Posts {
+		if pred(post) {
+			posts = append(posts, post)
+		}
+	}
+	return posts
+}
+
+// RankedPost is a post along with its position in a listing.
+type RankedPost struct {
+	*Post
+	// 1-based position of the post in the listing.
+	Rank int
+}
+
+// PostsWithRank returns the posts paired with their 1-based position in the listing,
+// which reflects their ranking, e.g. on the front page.
+func (t things) PostsWithRank() []RankedPost {
+	if len(t.Posts) == 0 {
+		return nil
+	}
+
+	ranked := make([]RankedPost, len(t.Posts))
+	for i, post := range t.Posts {
+		ranked[i] = RankedPost{Post: post, Rank: i + 1}
+	}
+	return ranked
+}
+
+// trackingParams are query parameters added to links to track where they were shared from.
+// They don't change what a link points to.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"igshid":  true,
+	"ref":     true,
+	"ref_src": true,
+	"si":      true,
+}
+
+// normalizeLinkURL returns u without what doesn't change what it points to, so that links
+// to the same page compare equal: the scheme (http or https), the www. prefix of the host,
+// tracking parameters, the fragment and a trailing slash. The remaining parameters are sorted.
+// If u cannot be parsed, it is returned as is.
+func normalizeLinkURL(u string) string {
+	parsed, err := url.Parse(strings.TrimSpace(u))
+	if err != nil || parsed.Host == "" {
+		return u
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") || trackingParams[key] {
+			query.Del(key)
+		}
+	}
+
+	normalized := host + strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if len(query) > 0 {
+		normalized += "?" + query.Encode()
+	}
+	return normalized
+}
+
+// GroupByURL groups the link posts by the URL they point to, e.g. to find reposts within a listing.
+// URLs are normalized, so links differing only by scheme, tracking parameters such as utm_source,
+// and the like end up in the same group. Posts are kept in the order of the listing.
+// Self posts are left out, since their URL is their own permalink.
+// Every link post is in a group, so groups with a single post aren't reposts.
+func (t things) GroupByURL() map[string][]*Post {
+	groups := make(map[string][]*Post)
+	for _, post := range t.Posts {
+		if post.IsSelfPost || post.URL == "" {
+			continue
+		}
+		key := normalizeLinkURL(post.URL)
+		groups[key] = append(groups[key], post)
+	}
+	return groups
+}
+
+// Record is a flat representation of a post or comment, suitable for exporting to
+// formats that require a fixed schema, e.g. CSV or columnar formats.
+type Record struct {
+	// t3 for posts, t1 for comments.
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Author string `json:"author"`
+	// Unix time at which the thing was created, 0 if unknown.
+	Created int64 `json:"created"`
+	Score   int   `json:"score"`
+	// Only set for posts.
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ToRecords returns the posts followed by the comments as flat records.
+// Other kinds of things are left out.
+func (t things) ToRecords() []Record {
+	var records []Record
+
+	unix := func(ts *Timestamp) int64 {
+		if ts == nil || ts.IsZero() {
+			return 0
+		}
+		return ts.Unix()
+	}
+
+	for _, post := range t.Posts {
+		records = append(records, Record{
+			Kind:    kindPost,
+			ID:      post.ID,
+			Author:  post.Author,
+			Created: unix(post.Created),
+			Score:   post.Score,
+			Title:   post.Title,
+			Body:    post.Body,
+		})
+	}
+
+	for _, comment := range t.Comments {
+		records = append(records, Record{
+			Kind:    kindComment,
+			ID:      comment.ID,
+			Author:  comment.Author,
+			Created: unix(comment.Created),
+			Score:   comment.Score,
+			Body:    comment.Body,
+		})
+	}
+
+	return records
+}
+
+// FilterComments returns the comments for which pred returns true.
+func (t things) FilterComments(pred func(*Comment) bool) []*Comment {
+	var comments []*Comment
+	for _, comment := range t.Comments {
+		if pred(comment) {
+			comments = append(comments, comment)
+		}
+	}
+	return comments
+}
+
+// MapPosts returns the result of calling fn on a copy of each post, leaving the posts untouched.
+// The copies are shallow, so fn must not modify the slices, maps, or pointed-to values of a post
+// in place, but may replace them. Posts for which fn returns nil are left out.
+func MapPosts(posts []*Post, fn func(*Post) *Post) []*Post {
+	mapped := make([]*Post, 0, len(posts))
+	for _, post := range posts {
+		if post == nil {
+			continue
+		}
+		cp := *post
+		if v := fn(&cp); v != nil {
+			mapped = append(mapped, v)
+		}
+	}
+	return mapped
+}
+
+// MapComments returns the result of calling fn on a copy of each comment, leaving the comments untouched.
+// The copies are shallow, like with MapPosts, so their replies are shared with the original comments.
+// Comments for which fn returns nil are left out.
+func MapComments(comments []*Comment, fn func(*Comment) *Comment) []*Comment {
+	mapped := make([]*Comment, 0, len(comments))
+	for _, comment := range comments {
+		if comment == nil {
+			continue
+		}
+		cp := *comment
+		if v := fn(&cp); v != nil {
+			mapped = append(mapped, v)
+		}
+	}
+	return mapped
+}
+
+// GroupCommentsByPost groups the comments by the full ID of the post they belong to, as given by
+// Comment.PostIDFromPermalink, keeping their order. This is useful for listings that mix comments
+// from many posts, such as the overview of a user. Comments whose post is unknown are left out.
+func GroupCommentsByPost(comments []*Comment) map[string][]*Comment {
+	groups := make(map[string][]*Comment)
+	for _, comment := range comments {
+		if comment == nil {
+			continue
+		}
+		if postID := comment.PostIDFromPermalink(); postID != "" {
+			groups[postID] = append(groups[postID], comment)
+		}
+	}
+	return groups
+}
+
+type trophyList []*Trophy
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *trophyList) UnmarshalJSON(b []byte) error {
+	root := new(struct {
+		Trophies []thing `json:"trophies"`
+	})
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	*l = make(trophyList, 0, len(root.Trophies))
+	for _, thing := range root.Trophies {
+		if trophy, ok := thing.Trophy(); ok {
+			*l = append(*l, trophy)
+		}
+	}
+
+	return nil
+}
+
+// userList is a list of users in a relationship with the current user or a subreddit,
+// e.g. friends, moderators, or approved submitters.
+type userList []*Relationship
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *userList) UnmarshalJSON(b []byte) error {
+	root := new(struct {
+		Relationships []*Relationship `json:"children"`
+	})
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	*l = root.Relationships
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (l userList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Relationships []*Relationship `json:"children"`
+	}{l})
+}
+
+// Comment is a comment posted by a user.
+type Comment struct {
+	ID      string     `json:"id,omitempty"`
+	FullID  string     `json:"name,omitempty"`
+	Created *Timestamp `json:"created_utc,omitempty"`
+	Edited  *Timestamp `json:"edited,omitempty"`
+
+	ParentID  string `json:"parent_id,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+
+	Body            string `json:"body,omitempty"`
+	Author          string `json:"author,omitempty"`
+	AuthorID        string `json:"author_fullname,omitempty"`
+	AuthorFlairText string `json:"author_flair_text,omitempty"`
+	AuthorFlairID   string `json:"author_flair_template_id,omitempty"`
+	// The author's flair as rich text, i.e. text mixed with emojis.
+	AuthorFlairRichText []FlairSegment `json:"author_flair_richtext,omitempty"`
+
+	// Moderator who approved the comment, and when.
+	// Only visible to moderators of the subreddit; nil otherwise.
+	ApprovedBy *string    `json:"approved_by,omitempty"`
+	ApprovedAt *Timestamp `json:"approved_at_utc,omitempty"`
+
+	SubredditName         string `json:"subreddit,omitempty"`
+	SubredditNamePrefixed string `json:"subreddit_name_prefixed,omitempty"`
+	SubredditID           string `json:"subreddit_id,omitempty"`
+
+	// Indicates if you've upvote/downvoted (true/false).
+	// If neither, it will be nil.
+	Likes *bool `json:"likes"`
+
+	Score            int `json:"score"`
+	Controversiality int `json:"controversiality"`
+
+	PostID string `json:"link_id,omitempty"`
+	// This doesn't appear consistently.
+	PostTitle string `json:"link_title,omitempty"`
+	// This doesn't appear consistently.
+	PostPermalink string `json:"link_permalink,omitempty"`
+	// This doesn't appear consistently.
+	PostAuthor string `json:"link_author,omitempty"`
+	// This doesn't appear consistently.
+	PostNumComments *int `json:"num_comments,omitempty"`
+
+	// The awards given to the comment.
+	Awardings []*Award `json:"all_awardings,omitempty"`
+
+	IsSubmitter bool `json:"is_submitter"`
+	ScoreHidden bool `json:"score_hidden"`
+	Saved       bool `json:"saved"`
+	Stickied    bool `json:"stickied"`
+	Locked      bool `json:"locked"`
+	CanGild     bool `json:"can_gild"`
+	NSFW        bool `json:"over_18"`
+
+	Replies Replies `json:"replies"`
+
+	// Fields returned by Reddit that the Comment type doesn't have.
+	// Only set when decoded with DecodeThingsCaptureExtra.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// How deeply nested the comment is in its post's comment tree.
+	depth int
+	// Why the comment is collapsed, if it is.
+	collapseReasonCode CollapseReason
+}
+
+// CollapseReason is the reason for which a comment is collapsed.
+type CollapseReason string
+
+// Reasons for which a comment may be collapsed.
+const (
+	CollapseReasonLowScore            CollapseReason = "LOW_SCORE"
+	CollapseReasonDeleted             CollapseReason = "DELETED"
+	CollapseReasonScoreBelowThreshold CollapseReason = "COMMENT_SCORE_BELOW_THRESHOLD"
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Comment) UnmarshalJSON(b []byte) error {
+	type comment Comment
+	root := &struct {
+		*comment
+		Depth              *int            `json:"depth"`
+		CollapseReasonCode *CollapseReason `json:"collapsed_reason_code"`
+	}{comment: (*comment)(c)}
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	if root.Depth != nil {
+		c.depth = *root.Depth
+	}
+	if root.CollapseReasonCode != nil {
+		c.collapseReasonCode = *root.CollapseReasonCode
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type comment Comment
+	return json.Marshal(&struct {
+		*comment
+		Depth              int            `json:"depth,omitempty"`
+		CollapseReasonCode CollapseReason `json:"collapsed_reason_code,omitempty"`
+	}{(*comment)(&c), c.depth, c.collapseReasonCode})
+}
+
+// Depth returns how deeply nested the comment is in its post's comment tree,
+// starting at 0 for top-level comments.
+// It is only known for comments fetched as part of a tree, e.g. via PostService.Get,
+// and is 0 for comments fetched on their own, e.g. from a user's profile.
+func (c *Comment) Depth() int {
+	return c.depth
+}
+
+// CollapseReasonCode returns the reason for which the comment is collapsed, e.g. CollapseReasonLowScore,
+// or an empty string if it isn't collapsed.
+func (c *Comment) CollapseReasonCode() CollapseReason {
+	return c.collapseReasonCode
+}
+
+// IndentPrefix returns unit repeated once per level of depth of the comment,
+// which is useful to indent comments when rendering a tree as text.
+func (c *Comment) IndentPrefix(unit string) string {
+	return strings.Repeat(unit, c.Depth())
+}
+
+// HasMore determines whether the comment has more replies to load in its reply tree.
+func (c *Comment) HasMore() bool {
+	return c.Replies.More != nil && len(c.Replies.More.Children) > 0
+}
+
+// WasEdited determines whether the comment was edited.
+// Reddit sends false instead of a timestamp for comments that never were.
+func (c *Comment) WasEdited() bool {
+	return c.Edited != nil && !c.Edited.IsZero()
+}
+
+// EditedAt returns the time the comment was last edited, if it was.
+func (c *Comment) EditedAt() (time.Time, bool) {
+	if !c.WasEdited() {
+		return time.Time{}, false
+	}
+	return c.Edited.Time, true
+}
+
+// NinjaEditWindow is the period after a comment is created during which editing it
+// is not considered a real edit, also known as a "ninja edit".
+// It is used by Comment.WasEditedAfter and can be changed to match a community's rules.
+var NinjaEditWindow = 3 * time.Minute
+
+// WasEditedAfter determines whether the comment was edited after the NinjaEditWindow,
+// i.e. whether the edit is significant.
+func (c *Comment) WasEditedAfter() bool {
+	if !c.WasEdited() || c.Created == nil {
+		return false
+	}
+	return c.Edited.SubTimestamp(c.Created) > NinjaEditWindow
+}
+
+// HasFlair determines whether the author of the comment has a flair, either as plain or rich text.
+func (c *Comment) HasFlair() bool {
+	if c.AuthorFlairText != "" {
+		return true
+	}
+	for _, segment := range c.AuthorFlairRichText {
+		if segment.Text != "" || segment.EmojiName != "" {
+			return true
+		}
+	}
+	return false
+}
+
+var redditLinkRegexp = regexp.MustCompile(`https?://(?:[\w-]+\.)?reddit\.com([^\w.-]|$)`)
+
+// RewriteLinks returns the body of the comment with links to reddit.com, including
+// its subdomains such as www.reddit.com, pointing to base instead, e.g. https://old.reddit.com.
+// Links to other sites are left untouched.
+func (c *Comment) RewriteLinks(base string) string {
+	base = strings.TrimSuffix(base, "/")
+	return redditLinkRegexp.ReplaceAllStringFunc(c.Body, func(link string) string {
+		match := redditLinkRegexp.FindStringSubmatch(link)
+		return base + match[1]
+	})
+}
+
+// QuotedText returns the blockquotes in the body of the comment, i.e. the lines starting with >,
+// without the > prefix. Consecutive quoted lines are joined with newlines into a single quote.
+func (c *Comment) QuotedText() []string {
+	var quotes []string
+	var current []string
+
+	for _, line := range strings.Split(c.Body, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, ">") {
+			if len(current) > 0 {
+				quotes = append(quotes, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		current = append(current, strings.TrimPrefix(trimmed, " "))
+	}
+
+	if len(current) > 0 {
+		quotes = append(quotes, strings.Join(current, "\n"))
+	}
+
+	return quotes
+}
+
+// CodeBlocks returns the code blocks in the body of the comment, without their fences or
+// indentation. Both fenced code blocks, delimited by lines starting with ``` or ~~~, and
+// indented code blocks, whose lines start with 4 spaces or a tab after a blank line, are supported.
+func (c *Comment) CodeBlocks() []string {
+	var blocks []string
+	var current []string
+
+	var fence string
+	indented := false
+	previousBlank := true
+
+	flush := func() {
+		// blank lines at the end of an indented block are not part of it
+		for len(current) > 0 && strings.TrimSpace(current[len(current)-1]) == "" {
+			current = current[:len(current)-1]
+		}
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(c.Body, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				flush()
+				fence = ""
+				previousBlank = false
+				continue
+			}
+			current = append(current, line)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if indented {
+				flush()
+				indented = false
+			}
+			fence = trimmed[:3]
+			continue
+		}
+
+		isIndented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+		switch {
+		case isIndented && (indented || previousBlank):
+			indented = true
+			if strings.HasPrefix(line, "\t") {
+				line = line[1:]
+			} else {
+				line = line[4:]
+			}
+			current = append(current, line)
+		case indented && trimmed == "":
+			// blank lines can be part of an indented block if it continues after them
+			current = append(current, "")
+		case indented:
+			flush()
+			indented = false
+		}
+
+		previousBlank = trimmed == ""
+	}
+
+	// an unclosed fence runs until the end of the body
+	flush()
+
+	return blocks
+}
+
+// PostIDFromPermalink returns the full ID of the post the comment belongs to.
+// It uses PostID when present, otherwise derives it from the comment's permalink,
+// e.g. /r/test/comments/abc123/title/def456/ belongs to t3_abc123.
+func (c *Comment) PostIDFromPermalink() string {
+	if c.PostID != "" {
+		return c.PostID
+	}
+
+	segments := strings.Split(strings.Trim(c.Permalink, "/"), "/")
+	for i, segment := range segments {
+		if segment == "comments" && i+1 < len(segments) && segments[i+1] != "" {
+			return kindPost + "_" + segments[i+1]
+		}
+	}
+
+	return ""
+}
+
+// addCommentToReplies traverses the comment tree to find the one
+// that the 2nd comment is replying to. It then adds it to its replies.
+func (c *Comment) addCommentToReplies(comment *Comment) {
+	if c.FullID == comment.ParentID {
+		c.Replies.Comments = append(c.Replies.Comments, comment)
+		return
+	}
+
+	for _, reply := range c.Replies.Comments {
+		reply.addCommentToReplies(comment)
+	}
+}
+
+func (c *Comment) addMoreToReplies(more *More) {
+	if c.FullID == more.ParentID {
+		c.Replies.More = more
+		return
+	}
+
+	for _, reply := range c.Replies.Comments {
+		reply.addMoreToReplies(more)
+	}
+}
+
+// Replies holds replies to a comment.
+// It contains both comments and "more" comments, which are entrypoints to other
+// comments that were left out.
+type Replies struct {
+	Comments []*Comment `json:"comments,omitempty"`
+	More     *More      `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Replies) UnmarshalJSON(data []byte) error {
+	// if a comment has no replies, its "replies" field is set to ""
+	if string(data) == `""` || string(data) == `null` {
+		r = nil
+		return nil
+	}
+
+	root := new(thing)
 	err := json.Unmarshal(data, root)
 	if err != nil {
 		return err
@@ -518,6 +1666,16 @@ type More struct {
 	Children []string `json:"children"`
 }
 
+// EstimatedComments returns the number of comments that loading the more would reveal, e.g. to
+// display "load N more comments". It is Count, unless Count is less than the number of children,
+// which happens when Reddit doesn't know it and sets it to 0, in which case that number is used.
+func (m *More) EstimatedComments() int {
+	if m.Count < len(m.Children) {
+		return len(m.Children)
+	}
+	return m.Count
+}
+
 // Post is a submitted post on Reddit.
 type Post struct {
 	ID      string     `json:"id,omitempty"`
@@ -539,6 +1697,10 @@ type Post struct {
 	UpvoteRatio      float32 `json:"upvote_ratio"`
 	NumberOfComments int     `json:"num_comments"`
 
+	// The comment sort suggested by the moderators, if any.
+	// One of: confidence (i.e. best), top, new, controversial, old, random, qa, live.
+	SuggestedSort string `json:"suggested_sort,omitempty"`
+
 	SubredditName         string `json:"subreddit,omitempty"`
 	SubredditNamePrefixed string `json:"subreddit_name_prefixed,omitempty"`
 	SubredditID           string `json:"subreddit_id,omitempty"`
@@ -547,12 +1709,323 @@ type Post struct {
 	Author   string `json:"author,omitempty"`
 	AuthorID string `json:"author_fullname,omitempty"`
 
+	// ID of the flair template the post's flair is based on, if any.
+	LinkFlairID string `json:"link_flair_template_id,omitempty"`
+
+	// Moderator who approved the post, and when.
+	// Only visible to moderators of the subreddit; nil otherwise.
+	ApprovedBy *string    `json:"approved_by,omitempty"`
+	ApprovedAt *Timestamp `json:"approved_at_utc,omitempty"`
+
+	// The awards given to the post.
+	Awardings []*Award `json:"all_awardings,omitempty"`
+
 	Spoiler    bool `json:"spoiler"`
+	IsOC       bool `json:"is_original_content"`
 	Locked     bool `json:"locked"`
+	Archived   bool `json:"archived"`
 	NSFW       bool `json:"over_18"`
 	IsSelfPost bool `json:"is_self"`
 	Saved      bool `json:"saved"`
 	Stickied   bool `json:"stickied"`
+
+	// The category the post was saved under, if any.
+	// Categories are only available to Reddit Premium users.
+	Category string `json:"category,omitempty"`
+
+	// Whether search engines are allowed to index the post.
+	// Archival tools should skip posts for which this is false.
+	IsRobotIndexable bool `json:"is_robot_indexable"`
+
+	IsVideo   bool `json:"is_video"`
+	IsGallery bool `json:"is_gallery"`
+
+	// URL of a small thumbnail of the post, or one of self, default, nsfw,
+	// spoiler, or image when there is none.
+	Thumbnail string   `json:"thumbnail,omitempty"`
+	Preview   *Preview `json:"preview,omitempty"`
+
+	Media *Media `json:"media,omitempty"`
+	// Same as Media, but with HTTPS URLs only.
+	SecureMedia *Media `json:"secure_media,omitempty"`
+	// The items of a gallery post, in the order they appear in.
+	GalleryData *GalleryData `json:"gallery_data,omitempty"`
+	// Information about the media items of a gallery post, keyed by their media ID.
+	MediaMetadata map[string]*MediaMetadata `json:"media_metadata,omitempty"`
+
+	// If the post is a crosspost, this is the full ID of the post it was crossposted from.
+	CrosspostParent string `json:"crosspost_parent,omitempty"`
+	// The post this crosspost was crossposted from. If that one is itself a crosspost,
+	// its own parent is included, and so on, up to MaxCrosspostDepth levels.
+	CrosspostParentList []*Post `json:"crosspost_parent_list,omitempty"`
+
+	// Fields returned by Reddit that the Post type doesn't have.
+	// Only set when decoded with DecodeThingsCaptureExtra.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MaxCrosspostDepth is the maximum number of levels of nested crossposts decoded from a post.
+// Crossposts nested deeper than this are dropped.
+var MaxCrosspostDepth = 3
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *Post) UnmarshalJSON(b []byte) error {
+	return p.unmarshalJSON(b, 0)
+}
+
+func (p *Post) unmarshalJSON(b []byte, depth int) error {
+	// posts are indexable unless stated otherwise, but an existing post
+	// keeps its value if the payload doesn't say
+	fresh := reflect.ValueOf(p).Elem().IsZero()
+
+	type post Post
+	root := &struct {
+		*post
+		IsRobotIndexable    *bool             `json:"is_robot_indexable"`
+		CrosspostParentList []json.RawMessage `json:"crosspost_parent_list"`
+	}{post: (*post)(p)}
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case root.IsRobotIndexable != nil:
+		p.IsRobotIndexable = *root.IsRobotIndexable
+	case fresh:
+		p.IsRobotIndexable = true
+	}
+
+	if root.CrosspostParentList == nil {
+		return nil
+	}
+
+	p.CrosspostParentList = nil
+	if depth >= MaxCrosspostDepth {
+		return nil
+	}
+
+	for _, raw := range root.CrosspostParentList {
+		parent := new(Post)
+		err = parent.unmarshalJSON(raw, depth+1)
+		if err != nil {
+			return err
+		}
+		p.CrosspostParentList = append(p.CrosspostParentList, parent)
+	}
+
+	return nil
+}
+
+// ApplyUpdate merges a partial representation of the post, e.g. {"score": 42}, onto it.
+// Fields absent from the update keep their current value.
+// The update can also be wrapped in a thing, i.e. {"kind": "t3", "data": {...}}.
+func (p *Post) ApplyUpdate(raw json.RawMessage) error {
+	root := new(struct {
+		Kind string          `json:"kind"`
+		Data json.RawMessage `json:"data"`
+	})
+
+	err := json.Unmarshal(raw, root)
+	if err != nil {
+		return err
+	}
+
+	if root.Kind != "" {
+		if root.Kind != kindPost {
+			return fmt.Errorf("cannot apply update of kind %q to a post", root.Kind)
+		}
+		raw = root.Data
+	}
+
+	update := new(struct {
+		FullID string `json:"name"`
+	})
+
+	err = json.Unmarshal(raw, update)
+	if err != nil {
+		return err
+	}
+
+	if update.FullID != "" && p.FullID != "" && update.FullID != p.FullID {
+		return fmt.Errorf("cannot apply update of %s to %s", update.FullID, p.FullID)
+	}
+
+	return p.unmarshalJSON(raw, 0)
+}
+
+// OriginSubreddit returns the name of the subreddit the post was originally submitted to.
+// For a crosspost, that's the subreddit of the post it was crossposted from, following
+// crossposts of crossposts. Otherwise, it's the post's own subreddit.
+// It returns an empty string if the origin is unknown, e.g. when the chain of crossposts
+// is deeper than what was decoded (see MaxCrosspostDepth).
+func (p *Post) OriginSubreddit() string {
+	origin := p
+	for len(origin.CrosspostParentList) > 0 && origin.CrosspostParentList[0] != nil {
+		origin = origin.CrosspostParentList[0]
+	}
+	// the deepest decoded post is itself a crosspost, so the chain was cut short
+	if origin.CrosspostParent != "" {
+		return ""
+	}
+	return origin.SubredditName
+}
+
+// WasEdited determines whether the post was edited.
+// Reddit sends false instead of a timestamp for posts that never were.
+func (p *Post) WasEdited() bool {
+	return p.Edited != nil && !p.Edited.IsZero()
+}
+
+// EditedAt returns the time the post was last edited, if it was.
+func (p *Post) EditedAt() (time.Time, bool) {
+	if !p.WasEdited() {
+		return time.Time{}, false
+	}
+	return p.Edited.Time, true
+}
+
+// MeetsAge reports whether the post was created at least min before now.
+// It returns false if the creation time of the post is unknown.
+func (p *Post) MeetsAge(min time.Duration, now time.Time) bool {
+	if p.Created == nil || p.Created.IsZero() {
+		return false
+	}
+	return now.Sub(p.Created.Time) >= min
+}
+
+// AuthorPostedYoungAccount reports whether the post was submitted less than maxAge after
+// the account of its author, u, was created, which is typical of throwaway accounts.
+// It returns false if u isn't the author of the post, or if either creation time is unknown.
+func AuthorPostedYoungAccount(p *Post, u *User, maxAge time.Duration) bool {
+	if p == nil || u == nil || !strings.EqualFold(p.Author, u.Name) {
+		return false
+	}
+	if p.Created == nil || p.Created.IsZero() || u.Created == nil || u.Created.IsZero() {
+		return false
+	}
+	return p.Created.SubTimestamp(u.Created) < maxAge
+}
+
+// IsCakeDayPost reports whether the post was submitted on the cake day of its author, i.e.
+// an anniversary of the creation of their account, in UTC. The day the account was created
+// doesn't count. If the creation time of the post is unknown, e.g. it is being written, now is used.
+// Accounts created on February 29 celebrate on February 28 in non-leap years.
+func IsCakeDayPost(p *Post, author *User, now time.Time) bool {
+	if author == nil || author.Created == nil || author.Created.IsZero() {
+		return false
+	}
+
+	posted := now
+	if p != nil && p.Created != nil && !p.Created.IsZero() {
+		posted = p.Created.Time
+	}
+
+	posted = posted.UTC()
+	created := author.Created.UTC()
+	if posted.Year() <= created.Year() {
+		return false
+	}
+
+	month, day := created.Month(), created.Day()
+	if month == time.February && day == 29 && !isLeapYear(posted.Year()) {
+		day = 28
+	}
+
+	return posted.Month() == month && posted.Day() == day
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// ApproxUpvotes estimates the number of upvotes of the post from its score and upvote ratio,
+// since Reddit doesn't expose the actual number. The estimate is only as accurate as the ratio,
+// which Reddit rounds, and scores are fuzzed to prevent vote manipulation.
+// If the ratio is exactly 0.5, the votes cannot be estimated and it returns the score, if positive.
+func (p *Post) ApproxUpvotes() int {
+	// score = ups - downs and ratio = ups / (ups + downs)
+	// so ups = ratio * score / (2*ratio - 1)
+	ratio := float64(p.UpvoteRatio)
+	if ratio == 0.5 {
+		return max0(p.Score)
+	}
+	return max0(int(math.Round(ratio * float64(p.Score) / (2*ratio - 1))))
+}
+
+// ApproxDownvotes estimates the number of downvotes of the post from its score and upvote ratio.
+// See ApproxUpvotes for the caveats of the estimate. It is never negative.
+func (p *Post) ApproxDownvotes() int {
+	return max0(p.ApproxUpvotes() - p.Score)
+}
+
+func max0(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Tags returns the labels to display next to the post's title, out of NSFW, Spoiler, and OC,
+// in that order, or nil if it has none of them.
+func (p *Post) Tags() []string {
+	var tags []string
+	if p.NSFW {
+		tags = append(tags, "NSFW")
+	}
+	if p.Spoiler {
+		tags = append(tags, "Spoiler")
+	}
+	if p.IsOC {
+		tags = append(tags, "OC")
+	}
+	return tags
+}
+
+// CommentGate reports whether new comments can be posted on the post.
+// If they can't, reason is either locked or archived; a post can be both,
+// in which case locked is returned.
+func (p *Post) CommentGate() (allowed bool, reason string) {
+	switch {
+	case p.Locked:
+		return false, "locked"
+	case p.Archived:
+		return false, "archived"
+	default:
+		return true, ""
+	}
+}
+
+// SetSaved updates the saved state and category of the post locally, e.g. to
+// reflect a call to PostService.Save in a UI before the request completes.
+// Unsaving the post also clears its category.
+func (p *Post) SetSaved(saved bool, category string) {
+	p.Saved = saved
+	if !saved {
+		category = ""
+	}
+	p.Category = category
+}
+
+// FlairCategory returns the category the post belongs to according to its flair,
+// given a mapping of flair template IDs to category labels.
+// It returns an empty string if the post's flair isn't in the mapping.
+func (p *Post) FlairCategory(mapping map[string]string) string {
+	if p.LinkFlairID == "" {
+		return ""
+	}
+	return mapping[p.LinkFlairID]
+}
+
+// CommentDelta returns the change in the number of comments of the post since
+// a previous snapshot of it, e.g. one fetched a few minutes earlier.
+// It returns 0 if the previous snapshot is of a different post.
+func (p *Post) CommentDelta(previous *Post) int {
+	if previous == nil || p.FullID != previous.FullID {
+		return 0
+	}
+	return p.NumberOfComments - previous.NumberOfComments
 }
 
 // Subreddit holds information about a subreddit
@@ -561,11 +2034,15 @@ type Subreddit struct {
 	FullID  string     `json:"name,omitempty"`
 	Created *Timestamp `json:"created_utc,omitempty"`
 
-	URL                  string `json:"url,omitempty"`
-	Name                 string `json:"display_name,omitempty"`
-	NamePrefixed         string `json:"display_name_prefixed,omitempty"`
-	Title                string `json:"title,omitempty"`
-	Description          string `json:"public_description,omitempty"`
+	URL             string `json:"url,omitempty"`
+	Name            string `json:"display_name,omitempty"`
+	NamePrefixed    string `json:"display_name_prefixed,omitempty"`
+	Title           string `json:"title,omitempty"`
+	Description     string `json:"public_description,omitempty"`
+	DescriptionHTML string `json:"public_description_html,omitempty"`
+	// The sidebar of the subreddit, in Markdown and HTML.
+	SidebarMD            string `json:"description,omitempty"`
+	SidebarHTML          string `json:"description_html,omitempty"`
 	Type                 string `json:"subreddit_type,omitempty"`
 	SuggestedCommentSort string `json:"suggested_comment_sort,omitempty"`
 
@@ -575,6 +2052,148 @@ type Subreddit struct {
 	UserIsMod       bool `json:"user_is_moderator"`
 	Subscribed      bool `json:"user_is_subscriber"`
 	Favorite        bool `json:"user_has_favorited"`
+
+	// The flair of the authenticated user in the subreddit, if any.
+	UserFlairText string `json:"user_flair_text,omitempty"`
+	UserFlairID   string `json:"user_flair_template_id,omitempty"`
+	// The flair of the authenticated user as rich text, with emojis.
+	UserFlairRichText []FlairSegment `json:"user_flair_richtext,omitempty"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *Subreddit) UnmarshalJSON(b []byte) error {
+	type subreddit Subreddit
+	err := json.Unmarshal(b, (*subreddit)(s))
+	if err != nil {
+		return err
+	}
+
+	// Reddit sends an empty array when the user has no flair in the subreddit.
+	if len(s.UserFlairRichText) == 0 {
+		s.UserFlairRichText = nil
+	}
+
+	return nil
+}
+
+// CanonicalName returns the name of the subreddit in lowercase.
+// Reddit treats subreddit names case-insensitively, so this is useful as a key
+// to deduplicate or cache subreddits.
+func (s *Subreddit) CanonicalName() string {
+	return strings.ToLower(s.Name)
+}
+
+// DefaultListingPath returns the path of the page to land on when navigating to the subreddit,
+// e.g. /r/golang/hot, or /user/spez/posts for the profile of a user.
+func (s *Subreddit) DefaultListingPath() string {
+	if s.isUserProfile() {
+		return "/user/" + strings.TrimPrefix(s.Name, "u_") + "/posts"
+	}
+	return "/r/" + s.Name + "/hot"
+}
+
+// isUserProfile reports whether the subreddit is the profile of a user.
+func (s *Subreddit) isUserProfile() bool {
+	// user profiles are subreddits named after the user, prefixed with u_
+	return s.Type == "user" || strings.HasPrefix(s.Name, "u_")
+}
+
+// SupportsModmail reports whether messages can be sent to the moderators of the subreddit.
+// User profiles don't have modmail, every other subreddit does.
+func (s *Subreddit) SupportsModmail() bool {
+	return !s.isUserProfile()
+}
+
+// FeedPath returns the path of the JSON feed of the subreddit's posts for the sort, e.g. hot.
+// The sort can be suffixed with a time filter, e.g. top-week, which gives /r/golang/top.json?t=week.
+// If the sort is empty, hot is used.
+func (s *Subreddit) FeedPath(sort string) string {
+	if sort == "" {
+		sort = "hot"
+	}
+
+	var period string
+	if i := strings.Index(sort, "-"); i >= 0 {
+		sort, period = sort[:i], sort[i+1:]
+	}
+
+	path := "/r/" + s.Name + "/" + sort + ".json"
+	if period != "" {
+		path += "?t=" + period
+	}
+	return path
+}
+
+// ActivityLevel classifies how active the subreddit is based on the ratio of active users to subscribers:
+//   - dead: less than 1 active user per 10,000 subscribers
+//   - quiet: less than 1 per 1,000
+//   - active: less than 1 per 100
+//   - bustling: at least 1 per 100
+//
+// It returns unknown if the number of active users isn't known.
+func (s *Subreddit) ActivityLevel() string {
+	if s.ActiveUserCount == nil {
+		return "unknown"
+	}
+
+	active := float64(*s.ActiveUserCount)
+	if active <= 0 {
+		return "dead"
+	}
+	if s.Subscribers <= 0 {
+		return "bustling"
+	}
+
+	switch ratio := active / float64(s.Subscribers); {
+	case ratio < 0.0001:
+		return "dead"
+	case ratio < 0.001:
+		return "quiet"
+	case ratio < 0.01:
+		return "active"
+	default:
+		return "bustling"
+	}
+}
+
+// IsNew reports whether the subreddit was created less than within before now.
+// Very young subreddits are often created for spam. It returns false if the
+// creation time of the subreddit is unknown.
+func (s *Subreddit) IsNew(within time.Duration, now time.Time) bool {
+	if s.Created == nil || s.Created.IsZero() {
+		return false
+	}
+	return now.Sub(s.Created.Time) < within
+}
+
+// RequiresNSFWConfirmation determines whether the user should confirm before entering the subreddit,
+// i.e. it is NSFW and the user is not subscribed to it.
+func (s *Subreddit) RequiresNSFWConfirmation() bool {
+	return s.NSFW && !s.Subscribed
+}
+
+// SubredditSnapshot holds the audience numbers of a subreddit at a point in time.
+type SubredditSnapshot struct {
+	FullID      string
+	Subscribers int
+	// nil if the number of active users wasn't known at the time.
+	ActiveUsers *int
+	At          time.Time
+}
+
+// Snapshot records the current number of subscribers and active users of the subreddit,
+// e.g. to track its growth over time. The snapshot doesn't reference the subreddit,
+// so it isn't affected by later changes to it.
+func (s *Subreddit) Snapshot(now time.Time) SubredditSnapshot {
+	snapshot := SubredditSnapshot{
+		FullID:      s.FullID,
+		Subscribers: s.Subscribers,
+		At:          now,
+	}
+	if s.ActiveUserCount != nil {
+		snapshot.ActiveUsers = Int(*s.ActiveUserCount)
+	}
+	return snapshot
 }
 
 // PostAndComments is a post and its comments.
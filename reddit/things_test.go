@@ -0,0 +1,1283 @@
+package reddit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPost_Approved(t *testing.T) {
+	post := new(Post)
+	err := json.Unmarshal([]byte(`{
+		"id": "abc123",
+		"name": "t3_abc123",
+		"approved_by": "testmod",
+		"approved_at_utc": 1595068800
+	}`), post)
+	require.NoError(t, err)
+	require.Equal(t, String("testmod"), post.ApprovedBy)
+	require.Equal(t, &Timestamp{time.Date(2020, 7, 18, 10, 40, 0, 0, time.UTC)}, post.ApprovedAt)
+
+	post = new(Post)
+	err = json.Unmarshal([]byte(`{"id": "abc123", "approved_by": null, "approved_at_utc": null}`), post)
+	require.NoError(t, err)
+	require.Nil(t, post.ApprovedBy)
+	require.Nil(t, post.ApprovedAt)
+}
+
+func TestComment_Approved(t *testing.T) {
+	comment := new(Comment)
+	err := json.Unmarshal([]byte(`{
+		"id": "def456",
+		"name": "t1_def456",
+		"approved_by": "testmod",
+		"approved_at_utc": 1595068800,
+		"replies": ""
+	}`), comment)
+	require.NoError(t, err)
+	require.Equal(t, String("testmod"), comment.ApprovedBy)
+	require.Equal(t, &Timestamp{time.Date(2020, 7, 18, 10, 40, 0, 0, time.UTC)}, comment.ApprovedAt)
+
+	comment = new(Comment)
+	err = json.Unmarshal([]byte(`{"id": "def456", "approved_by": null, "approved_at_utc": null, "replies": ""}`), comment)
+	require.NoError(t, err)
+	require.Nil(t, comment.ApprovedBy)
+	require.Nil(t, comment.ApprovedAt)
+}
+
+func TestComment_PostIDFromPermalink(t *testing.T) {
+	comment := &Comment{
+		PostID:    "t3_abc123",
+		Permalink: "/r/test/comments/abc123/test/def456/",
+	}
+	require.Equal(t, "t3_abc123", comment.PostIDFromPermalink())
+
+	comment = &Comment{Permalink: "/r/test/comments/abc123/test/def456/"}
+	require.Equal(t, "t3_abc123", comment.PostIDFromPermalink())
+
+	comment = &Comment{Permalink: "https://www.reddit.com/r/test/comments/xyz789/test/def456/"}
+	require.Equal(t, "t3_xyz789", comment.PostIDFromPermalink())
+
+	comment = &Comment{}
+	require.Empty(t, comment.PostIDFromPermalink())
+}
+
+func TestThings_PartitionNSFW(t *testing.T) {
+	var tt things
+	err := json.Unmarshal([]byte(`[
+		{"kind": "t3", "data": {"id": "p1", "over_18": false}},
+		{"kind": "t3", "data": {"id": "p2", "over_18": true}},
+		{"kind": "t3", "data": {"id": "p3", "over_18": true}},
+		{"kind": "t1", "data": {"id": "c1", "over_18": true, "replies": ""}},
+		{"kind": "t1", "data": {"id": "c2", "over_18": false, "replies": ""}},
+		{"kind": "t5", "data": {"id": "s1", "over18": true}}
+	]`), &tt)
+	require.NoError(t, err)
+
+	sfw, nsfw := tt.PartitionNSFW()
+	require.Len(t, sfw.Posts, 1)
+	require.Len(t, sfw.Comments, 1)
+	require.Len(t, sfw.Subreddits, 1)
+	require.Len(t, nsfw.Posts, 2)
+	require.Len(t, nsfw.Comments, 1)
+	require.Len(t, nsfw.Subreddits, 0)
+
+	require.Equal(t, "p1", sfw.Posts[0].ID)
+	require.Equal(t, "c2", sfw.Comments[0].ID)
+	require.Equal(t, "p2", nsfw.Posts[0].ID)
+	require.Equal(t, "p3", nsfw.Posts[1].ID)
+	require.Equal(t, "c1", nsfw.Comments[0].ID)
+
+	// the original things must not be affected
+	require.Len(t, tt.Posts, 3)
+	require.Len(t, tt.Comments, 2)
+}
+
+func TestPost_ApplyUpdate(t *testing.T) {
+	post := &Post{
+		ID:       "abc123",
+		FullID:   "t3_abc123",
+		Title:    "Test",
+		Score:    1,
+		Likes:    nil,
+		Saved:    true,
+		Stickied: true,
+	}
+
+	err := post.ApplyUpdate(json.RawMessage(`{"score": 42}`))
+	require.NoError(t, err)
+	require.Equal(t, &Post{
+		ID:       "abc123",
+		FullID:   "t3_abc123",
+		Title:    "Test",
+		Score:    42,
+		Saved:    true,
+		Stickied: true,
+	}, post)
+
+	err = post.ApplyUpdate(json.RawMessage(`{"kind": "t3", "data": {"name": "t3_abc123", "score": 43, "likes": true}}`))
+	require.NoError(t, err)
+	require.Equal(t, 43, post.Score)
+	require.Equal(t, Bool(true), post.Likes)
+	require.Equal(t, "Test", post.Title)
+
+	err = post.ApplyUpdate(json.RawMessage(`{"kind": "t1", "data": {"score": 1}}`))
+	require.EqualError(t, err, `cannot apply update of kind "t1" to a post`)
+
+	err = post.ApplyUpdate(json.RawMessage(`{"name": "t3_def456", "score": 1}`))
+	require.EqualError(t, err, "cannot apply update of t3_def456 to t3_abc123")
+	require.Equal(t, 43, post.Score)
+
+	err = post.ApplyUpdate(json.RawMessage(`[]`))
+	require.Error(t, err)
+}
+
+func TestThings_Filter(t *testing.T) {
+	tt := things{
+		Posts: []*Post{
+			{ID: "p1", Score: 5},
+			{ID: "p2", Score: 100},
+			{ID: "p3", Score: 50},
+		},
+		Comments: []*Comment{
+			{ID: "c1", Score: 10},
+			{ID: "c2", Score: -3},
+		},
+	}
+
+	posts := tt.FilterPosts(func(p *Post) bool { return p.Score >= 50 })
+	require.Equal(t, []*Post{tt.Posts[1], tt.Posts[2]}, posts)
+
+	comments := tt.FilterComments(func(c *Comment) bool { return c.Score >= 0 })
+	require.Equal(t, []*Comment{tt.Comments[0]}, comments)
+
+	require.Nil(t, tt.FilterPosts(func(p *Post) bool { return p.Score > 1000 }))
+	require.Nil(t, things{}.FilterComments(func(c *Comment) bool { return true }))
+}
+
+func TestPost_UnmarshalJSON_Crossposts(t *testing.T) {
+	blob, err := readFileContents("../testdata/post/crosspost.json")
+	require.NoError(t, err)
+
+	post := new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+	require.Equal(t, "t3_post4", post.FullID)
+	require.Equal(t, "t3_post3", post.CrosspostParent)
+
+	// only 3 levels of crossposts are decoded by default
+	depth := 0
+	for p := post; len(p.CrosspostParentList) > 0; p = p.CrosspostParentList[0] {
+		depth++
+	}
+	require.Equal(t, 3, depth)
+	require.Equal(t, "t3_post1", post.CrosspostParentList[0].CrosspostParentList[0].CrosspostParentList[0].FullID)
+
+	defer func(max int) { MaxCrosspostDepth = max }(MaxCrosspostDepth)
+
+	MaxCrosspostDepth = 1
+	post = new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+	require.Len(t, post.CrosspostParentList, 1)
+	require.Equal(t, "t3_post3", post.CrosspostParentList[0].FullID)
+	require.Equal(t, "t3_post2", post.CrosspostParentList[0].CrosspostParent)
+	require.Nil(t, post.CrosspostParentList[0].CrosspostParentList)
+
+	MaxCrosspostDepth = 10
+	post = new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+	require.Equal(t, "t3_post0", post.CrosspostParentList[0].CrosspostParentList[0].CrosspostParentList[0].CrosspostParentList[0].FullID)
+}
+
+func TestPost_MeetsAge(t *testing.T) {
+	created := time.Date(2020, 7, 18, 10, 0, 0, 0, time.UTC)
+	post := &Post{Created: &Timestamp{created}}
+
+	require.True(t, post.MeetsAge(time.Hour, created.Add(2*time.Hour)))
+	require.True(t, post.MeetsAge(time.Hour, created.Add(time.Hour)))
+	require.False(t, post.MeetsAge(time.Hour, created.Add(time.Hour-time.Second)))
+	require.True(t, post.MeetsAge(0, created))
+	require.False(t, post.MeetsAge(time.Hour, created.Add(-time.Hour)))
+
+	require.False(t, new(Post).MeetsAge(0, created))
+	require.False(t, (&Post{Created: &Timestamp{}}).MeetsAge(0, created))
+}
+
+func TestComment_HasFlair(t *testing.T) {
+	require.False(t, new(Comment).HasFlair())
+	require.False(t, (&Comment{AuthorFlairRichText: []FlairSegment{}}).HasFlair())
+	require.False(t, (&Comment{AuthorFlairRichText: []FlairSegment{{Type: "text"}}}).HasFlair())
+
+	require.True(t, (&Comment{AuthorFlairText: "Flair"}).HasFlair())
+	require.True(t, (&Comment{AuthorFlairRichText: []FlairSegment{{Type: "text", Text: "Flair"}}}).HasFlair())
+	require.True(t, (&Comment{AuthorFlairRichText: []FlairSegment{{Type: "emoji", EmojiName: ":upvote:", EmojiURL: "https://emoji.redditmedia.com/upvote.png"}}}).HasFlair())
+
+	comment := new(Comment)
+	err := json.Unmarshal([]byte(`{
+		"author_flair_text": null,
+		"author_flair_richtext": [
+			{"e": "emoji", "a": ":snoo:", "u": "https://emoji.redditmedia.com/snoo.png"},
+			{"e": "text", "t": " Regular"}
+		]
+	}`), comment)
+	require.NoError(t, err)
+	require.True(t, comment.HasFlair())
+	require.Equal(t, []FlairSegment{
+		{Type: "emoji", EmojiName: ":snoo:", EmojiURL: "https://emoji.redditmedia.com/snoo.png"},
+		{Type: "text", Text: " Regular"},
+	}, comment.AuthorFlairRichText)
+}
+
+func TestSubreddit_RequiresNSFWConfirmation(t *testing.T) {
+	require.True(t, (&Subreddit{NSFW: true}).RequiresNSFWConfirmation())
+	require.False(t, (&Subreddit{NSFW: true, Subscribed: true}).RequiresNSFWConfirmation())
+	require.False(t, (&Subreddit{}).RequiresNSFWConfirmation())
+	require.False(t, (&Subreddit{Subscribed: true}).RequiresNSFWConfirmation())
+}
+
+func TestPost_CommentDelta(t *testing.T) {
+	previous := &Post{FullID: "t3_abc123", NumberOfComments: 10}
+
+	require.Equal(t, 5, (&Post{FullID: "t3_abc123", NumberOfComments: 15}).CommentDelta(previous))
+	require.Equal(t, -2, (&Post{FullID: "t3_abc123", NumberOfComments: 8}).CommentDelta(previous))
+	require.Equal(t, 0, (&Post{FullID: "t3_abc123", NumberOfComments: 10}).CommentDelta(previous))
+
+	require.Equal(t, 0, (&Post{FullID: "t3_def456", NumberOfComments: 15}).CommentDelta(previous))
+	require.Equal(t, 0, (&Post{FullID: "t3_abc123", NumberOfComments: 15}).CommentDelta(nil))
+}
+
+var rawThings = []byte(`[
+	{"kind": "t3", "data": {"id": "abc123", "name": "t3_abc123", "title": "Post", "score": 10}},
+	{"kind": "t1", "data": {"id": "def456", "name": "t1_def456", "body": "Comment", "replies": ""}},
+	{"kind": "t5", "data": {"id": "ghi789", "name": "t5_ghi789", "display_name": "test"}},
+	{"kind": "t3", "data": {"id": "jkl012", "name": "t3_jkl012", "title": "Another post", "score": 5}}
+]`)
+
+func TestDecodeThingsPooled(t *testing.T) {
+	var expected things
+	err := json.Unmarshal(rawThings, &expected)
+	require.NoError(t, err)
+
+	pool := &sync.Pool{New: func() interface{} { return new([]thing) }}
+	for i := 0; i < 3; i++ {
+		actual, err := DecodeThingsPooled(rawThings, pool)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	// results stay valid after the pooled slice is reused
+	first, err := DecodeThingsPooled(rawThings, pool)
+	require.NoError(t, err)
+	_, err = DecodeThingsPooled([]byte(`[{"kind": "t3", "data": {"id": "other"}}]`), pool)
+	require.NoError(t, err)
+	require.Equal(t, expected, first)
+
+	actual, err := DecodeThingsPooled(rawThings, new(sync.Pool))
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = DecodeThingsPooled([]byte(`[{"kind": "t9", "data": {}}]`), pool)
+	require.EqualError(t, err, `unrecognized kind: "t9"`)
+}
+
+func TestDecodeThingsLimited(t *testing.T) {
+	raw := []byte(`[
+		{"kind": "t3", "data": {"id": "1"}},
+		{"kind": "t1", "data": {"id": "2"}},
+		{"kind": "t3", "data": {"id": "3"}},
+		{"kind": "t9", "data": {}}
+	`)
+
+	// the rest of the payload isn't even read, so it doesn't matter that it's invalid
+	actual, err := DecodeThingsLimited(raw, 2)
+	require.NoError(t, err)
+	require.Len(t, actual.Posts, 1)
+	require.Len(t, actual.Comments, 1)
+	require.Equal(t, "1", actual.Posts[0].ID)
+	require.Equal(t, "2", actual.Comments[0].ID)
+
+	actual, err = DecodeThingsLimited(raw, 0)
+	require.NoError(t, err)
+	require.Empty(t, actual.Posts)
+
+	_, err = DecodeThingsLimited(raw, 10)
+	require.EqualError(t, err, `unrecognized kind: "t9"`)
+
+	var expected things
+	err = json.Unmarshal(rawThings, &expected)
+	require.NoError(t, err)
+	actual, err = DecodeThingsLimited(rawThings, 1000)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = DecodeThingsLimited([]byte(`{"kind": "t3", "data": {}}`), 10)
+	require.EqualError(t, err, "expected an array of things, got {")
+}
+
+func BenchmarkDecodeThings(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var t things
+		if err := json.Unmarshal(rawThings, &t); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeThingsPooled(b *testing.B) {
+	pool := &sync.Pool{New: func() interface{} { return new([]thing) }}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeThingsPooled(rawThings, pool); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestComment_IndentPrefix(t *testing.T) {
+	pc := new(PostAndComments)
+	err := json.Unmarshal([]byte(`[
+		{"kind": "Listing", "data": {"children": [{"kind": "t3", "data": {"id": "abc"}}]}},
+		{"kind": "Listing", "data": {"children": [
+			{"kind": "t1", "data": {"id": "c0", "depth": 0, "replies": {"kind": "Listing", "data": {"children": [
+				{"kind": "t1", "data": {"id": "c1", "depth": 1, "replies": {"kind": "Listing", "data": {"children": [
+					{"kind": "t1", "data": {"id": "c2", "depth": 2, "replies": {"kind": "Listing", "data": {"children": [
+						{"kind": "t1", "data": {"id": "c3", "depth": 3, "replies": ""}}
+					]}}}}
+				]}}}}
+			]}}}}
+		]}}
+	]`), pc)
+	require.NoError(t, err)
+
+	c0 := pc.Comments[0]
+	c1 := c0.Replies.Comments[0]
+	c3 := c1.Replies.Comments[0].Replies.Comments[0]
+
+	require.Equal(t, 0, c0.Depth())
+	require.Equal(t, "", c0.IndentPrefix("  "))
+	require.Equal(t, 1, c1.Depth())
+	require.Equal(t, "  ", c1.IndentPrefix("  "))
+	require.Equal(t, 3, c3.Depth())
+	require.Equal(t, "> > > ", c3.IndentPrefix("> "))
+
+	require.Equal(t, "", new(Comment).IndentPrefix("  "))
+}
+
+func TestSubreddit_UserFlair(t *testing.T) {
+	subreddit := new(Subreddit)
+	err := json.Unmarshal([]byte(`{
+		"display_name": "test",
+		"user_flair_text": ":gopher: Moderator",
+		"user_flair_template_id": "024b2b66-05ca-11e1-96f4-12313d096aae",
+		"user_flair_richtext": [
+			{"e": "emoji", "a": ":gopher:", "u": "https://emoji.redditmedia.com/gopher.png"},
+			{"e": "text", "t": " Moderator"}
+		]
+	}`), subreddit)
+	require.NoError(t, err)
+	require.Equal(t, ":gopher: Moderator", subreddit.UserFlairText)
+	require.Equal(t, "024b2b66-05ca-11e1-96f4-12313d096aae", subreddit.UserFlairID)
+	require.Equal(t, []FlairSegment{
+		{Type: "emoji", EmojiName: ":gopher:", EmojiURL: "https://emoji.redditmedia.com/gopher.png"},
+		{Type: "text", Text: " Moderator"},
+	}, subreddit.UserFlairRichText)
+
+	subreddit = new(Subreddit)
+	err = json.Unmarshal([]byte(`{
+		"display_name": "test",
+		"user_flair_text": null,
+		"user_flair_template_id": null,
+		"user_flair_richtext": []
+	}`), subreddit)
+	require.NoError(t, err)
+	require.Empty(t, subreddit.UserFlairText)
+	require.Empty(t, subreddit.UserFlairID)
+	require.Nil(t, subreddit.UserFlairRichText)
+}
+
+func TestThings_PostsWithRank(t *testing.T) {
+	tt := things{
+		Posts: []*Post{
+			{FullID: "t3_first"},
+			{FullID: "t3_second"},
+			{FullID: "t3_third"},
+		},
+		Comments: []*Comment{{FullID: "t1_comment"}},
+	}
+
+	ranked := tt.PostsWithRank()
+	require.Len(t, ranked, 3)
+	for i, post := range ranked {
+		require.Equal(t, i+1, post.Rank)
+		require.Equal(t, tt.Posts[i], post.Post)
+	}
+	require.Equal(t, "t3_third", ranked[2].FullID)
+
+	require.Nil(t, things{}.PostsWithRank())
+}
+
+func TestDecodeStrictListing(t *testing.T) {
+	blob, err := readFileContents("../testdata/listings/posts.json")
+	require.NoError(t, err)
+
+	page, err := DecodeStrictListing([]byte(blob))
+	require.NoError(t, err)
+	require.Len(t, page.Posts, 2)
+	require.Equal(t, "t3_i2gvg4", page.Posts[0].FullID)
+	require.Empty(t, page.Comments)
+
+	page, err = DecodeStrictListing([]byte(`{"kind": "Listing", "data": {"children": [
+		{"kind": "t4", "data": {"id": "m0", "name": "t4_m0", "subject": "test"}},
+		{"kind": "t6", "data": {"name": "Verified Email", "award_id": "o"}}
+	]}}`))
+	require.NoError(t, err)
+	require.Len(t, page.Messages, 1)
+	require.Equal(t, "t4_m0", page.Messages[0].FullID)
+	require.Len(t, page.Trophies, 1)
+	require.Equal(t, "Verified Email", page.Trophies[0].Name)
+
+	_, err = DecodeStrictListing([]byte(`{"kind": "t3", "data": {"id": "abc123", "name": "t3_abc123"}}`))
+	require.EqualError(t, err, `expected kind "Listing", got "t3"`)
+
+	_, err = DecodeStrictListing([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestComment_RewriteLinks(t *testing.T) {
+	comment := &Comment{Body: "See https://www.reddit.com/r/golang/comments/abc123/title/ and " +
+		"[this](http://reddit.com/r/test) or https://np.reddit.com/u/test, " +
+		"https://reddit.com, https://example.com/reddit.com and https://notreddit.com/r/test " +
+		"or https://reddit.com.example.com/r/test"}
+
+	require.Equal(t, "See https://old.reddit.com/r/golang/comments/abc123/title/ and "+
+		"[this](https://old.reddit.com/r/test) or https://old.reddit.com/u/test, "+
+		"https://old.reddit.com, https://example.com/reddit.com and https://notreddit.com/r/test "+
+		"or https://reddit.com.example.com/r/test", comment.RewriteLinks("https://old.reddit.com/"))
+
+	comment = &Comment{Body: "No links here, just https://example.com"}
+	require.Equal(t, comment.Body, comment.RewriteLinks("https://old.reddit.com"))
+}
+
+func TestComment_WasEditedAfter(t *testing.T) {
+	created := time.Date(2020, 7, 18, 10, 0, 0, 0, time.UTC)
+	newComment := func(edited time.Duration) *Comment {
+		return &Comment{
+			Created: &Timestamp{created},
+			Edited:  &Timestamp{created.Add(edited)},
+		}
+	}
+
+	require.False(t, newComment(time.Minute).WasEditedAfter())
+	require.False(t, newComment(3*time.Minute).WasEditedAfter())
+	require.True(t, newComment(5*time.Minute).WasEditedAfter())
+
+	// not edited
+	require.False(t, (&Comment{Created: &Timestamp{created}, Edited: &Timestamp{}}).WasEditedAfter())
+	require.False(t, (&Comment{Created: &Timestamp{created}}).WasEditedAfter())
+
+	defer func(window time.Duration) { NinjaEditWindow = window }(NinjaEditWindow)
+
+	NinjaEditWindow = 10 * time.Minute
+	require.False(t, newComment(5*time.Minute).WasEditedAfter())
+	require.True(t, newComment(11*time.Minute).WasEditedAfter())
+
+	NinjaEditWindow = 0
+	require.True(t, newComment(time.Second).WasEditedAfter())
+}
+
+func TestPost_IsRobotIndexable(t *testing.T) {
+	post := new(Post)
+	err := json.Unmarshal([]byte(`{"id": "abc123", "is_robot_indexable": false}`), post)
+	require.NoError(t, err)
+	require.False(t, post.IsRobotIndexable)
+
+	post = new(Post)
+	err = json.Unmarshal([]byte(`{"id": "abc123", "is_robot_indexable": true}`), post)
+	require.NoError(t, err)
+	require.True(t, post.IsRobotIndexable)
+
+	// indexable by default
+	post = new(Post)
+	err = json.Unmarshal([]byte(`{"id": "abc123", "crosspost_parent_list": [{"id": "def456"}]}`), post)
+	require.NoError(t, err)
+	require.True(t, post.IsRobotIndexable)
+	require.True(t, post.CrosspostParentList[0].IsRobotIndexable)
+
+	// decoding into an existing post without the field doesn't reset it
+	post = &Post{ID: "abc123", IsRobotIndexable: false}
+	err = json.Unmarshal([]byte(`{"id": "abc123", "score": 10}`), post)
+	require.NoError(t, err)
+	require.False(t, post.IsRobotIndexable)
+	require.Equal(t, 10, post.Score)
+
+	// partial updates don't reset it
+	post = &Post{ID: "abc123", IsRobotIndexable: false}
+	err = post.ApplyUpdate(json.RawMessage(`{"score": 10}`))
+	require.NoError(t, err)
+	require.False(t, post.IsRobotIndexable)
+}
+
+func TestThings_ToRecords(t *testing.T) {
+	tt := things{
+		Posts: []*Post{
+			{
+				ID:      "abc123",
+				FullID:  "t3_abc123",
+				Created: &Timestamp{time.Date(2020, 7, 18, 10, 0, 0, 0, time.UTC)},
+				Title:   "Title",
+				Body:    "Text",
+				Score:   42,
+				Author:  "testuser",
+			},
+		},
+		Comments: []*Comment{
+			{
+				ID:     "def456",
+				FullID: "t1_def456",
+				Body:   "Comment",
+				Score:  -3,
+				Author: "testuser2",
+			},
+		},
+		Subreddits: []*Subreddit{{Name: "test"}},
+	}
+
+	require.Equal(t, []Record{
+		{
+			Kind:    "t3",
+			ID:      "abc123",
+			Author:  "testuser",
+			Created: 1595066400,
+			Score:   42,
+			Title:   "Title",
+			Body:    "Text",
+		},
+		{
+			Kind:   "t1",
+			ID:     "def456",
+			Author: "testuser2",
+			Score:  -3,
+			Body:   "Comment",
+		},
+	}, tt.ToRecords())
+
+	require.Nil(t, things{}.ToRecords())
+}
+
+func TestSubreddit_CanonicalName(t *testing.T) {
+	require.Equal(t, "askreddit", (&Subreddit{Name: "AskReddit"}).CanonicalName())
+	require.Equal(t, "askreddit", (&Subreddit{Name: "askreddit"}).CanonicalName())
+	require.Equal(t, "golang", (&Subreddit{Name: "GOLANG"}).CanonicalName())
+	require.Equal(t, "", new(Subreddit).CanonicalName())
+}
+
+func TestPost_SetSaved(t *testing.T) {
+	post := new(Post)
+
+	post.SetSaved(true, "recipes")
+	require.True(t, post.Saved)
+	require.Equal(t, "recipes", post.Category)
+
+	post.SetSaved(true, "")
+	require.True(t, post.Saved)
+	require.Empty(t, post.Category)
+
+	post.SetSaved(true, "recipes")
+	post.SetSaved(false, "recipes")
+	require.False(t, post.Saved)
+	require.Empty(t, post.Category)
+}
+
+func TestRoundTrip(t *testing.T) {
+	fixtures := []string{
+		"listings/posts.json",
+		"listings/posts-comments-subreddits.json",
+		"subreddit/search-posts.json",
+		"user/overview.json",
+		"moderation/actions.json",
+		"live-thread/discussions.json",
+	}
+
+	for _, fixture := range fixtures {
+		blob, err := readFileContents("../testdata/" + fixture)
+		require.NoError(t, err, fixture)
+
+		out, err := RoundTrip([]byte(blob))
+		require.NoError(t, err, fixture)
+
+		// encoding is stable
+		again, err := RoundTrip(out)
+		require.NoError(t, err, fixture)
+		require.Equal(t, string(out), string(again), fixture)
+
+		// nothing known to the package is lost
+		expected, actual := new(thing), new(thing)
+		require.NoError(t, json.Unmarshal([]byte(blob), expected), fixture)
+		require.NoError(t, json.Unmarshal(out, actual), fixture)
+		require.Equal(t, expected, actual, fixture)
+	}
+}
+
+func TestRoundTrip_Replies(t *testing.T) {
+	blob := []byte(`{"kind": "Listing", "data": {"after": "t1_c0", "children": [
+		{"kind": "t1", "data": {"id": "c0", "name": "t1_c0", "depth": 0, "edited": false, "replies": {"kind": "Listing", "data": {"children": [
+			{"kind": "t1", "data": {"id": "c1", "name": "t1_c1", "depth": 1, "edited": 1595066400, "replies": ""}},
+			{"kind": "more", "data": {"id": "c2", "name": "t1_c2", "parent_id": "t1_c0", "count": 2, "depth": 1, "children": ["c2", "c3"]}}
+		]}}}}
+	]}}`)
+
+	out, err := RoundTrip(blob)
+	require.NoError(t, err)
+
+	expected, actual := new(thing), new(thing)
+	require.NoError(t, json.Unmarshal(blob, expected))
+	require.NoError(t, json.Unmarshal(out, actual))
+	require.Equal(t, expected, actual)
+
+	l, _ := actual.Listing()
+	require.Equal(t, "t1_c0", l.After())
+	require.Equal(t, 1, l.Comments()[0].Replies.Comments[0].Depth())
+	require.Equal(t, []string{"c2", "c3"}, l.Comments()[0].Replies.More.Children)
+
+	_, err = RoundTrip([]byte(`{"kind": "t3", "data": {"id": "abc123"}}`))
+	require.EqualError(t, err, `expected kind "Listing", got "t3"`)
+
+	_, err = RoundTrip([]byte(`{`))
+	require.Error(t, err)
+}
+
+func TestRoundTrip_MessageReplies(t *testing.T) {
+	blob := []byte(`{"kind": "Listing", "data": {"children": [
+		{"kind": "t4", "data": {"id": "m0", "name": "t4_m0", "subject": "test", "replies": {"kind": "Listing", "data": {"children": [
+			{"kind": "t4", "data": {"id": "m1", "name": "t4_m1", "parent_id": "t4_m0", "replies": ""}}
+		]}}}}
+	]}}`)
+
+	out, err := RoundTrip(blob)
+	require.NoError(t, err)
+
+	page, err := DecodeStrictListing(out)
+	require.NoError(t, err)
+	require.Len(t, page.Messages, 1)
+	require.Len(t, page.Messages[0].Replies, 1)
+	require.Equal(t, "t4_m1", page.Messages[0].Replies[0].FullID)
+}
+
+func TestComment_MarshalJSON(t *testing.T) {
+	comment := Comment{
+		ID:      "c0",
+		Replies: Replies{Comments: []*Comment{{ID: "c1", depth: 1}}},
+		depth:   2,
+	}
+
+	// a comment value is encoded the same as a pointer to it
+	byValue, err := json.Marshal(comment)
+	require.NoError(t, err)
+	byPointer, err := json.Marshal(&comment)
+	require.NoError(t, err)
+	require.JSONEq(t, string(byPointer), string(byValue))
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(byValue, &fields))
+	require.Equal(t, `2`, string(fields["depth"]))
+	// json.Marshal encodes the replies as an array, unlike the Reddit API
+	require.Equal(t, byte('['), fields["replies"][0])
+	require.NotContains(t, fields, "all_awardings")
+	require.NotContains(t, fields, "author_flair_richtext")
+
+	fields = nil
+	b, err := json.Marshal(Comment{ID: "c2"})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &fields))
+	require.Equal(t, `null`, string(fields["replies"]))
+}
+
+func TestRoundTrip_Corpus(t *testing.T) {
+	files, err := filepath.Glob("../testdata/fuzz/roundtrip/corpus/*")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		blob, err := ioutil.ReadFile(file)
+		require.NoError(t, err, file)
+
+		out, err := RoundTrip(blob)
+		require.NoError(t, err, file)
+
+		again, err := RoundTrip(out)
+		require.NoError(t, err, file)
+		require.Equal(t, string(out), string(again), file)
+	}
+}
+
+func TestThings_NormalizeFullIDs(t *testing.T) {
+	var tt things
+	err := json.Unmarshal([]byte(`[
+		{"kind": "t3", "data": {"id": "abc123"}},
+		{"kind": "t3", "data": {"id": "def456", "name": "t3_def456"}},
+		{"kind": "t1", "data": {"id": "ghi789", "replies": ""}},
+		{"kind": "t1", "data": {"replies": ""}}
+	]`), &tt)
+	require.NoError(t, err)
+
+	tt.NormalizeFullIDs()
+	require.Equal(t, "t3_abc123", tt.Posts[0].FullID)
+	require.Equal(t, "t3_def456", tt.Posts[1].FullID)
+	require.Equal(t, "t1_ghi789", tt.Comments[0].FullID)
+	require.Empty(t, tt.Comments[1].FullID)
+}
+
+func TestPost_OriginSubreddit(t *testing.T) {
+	require.Equal(t, "test", (&Post{SubredditName: "test"}).OriginSubreddit())
+
+	crosspost := &Post{
+		SubredditName:   "test2",
+		CrosspostParent: "t3_abc123",
+		CrosspostParentList: []*Post{
+			{FullID: "t3_abc123", SubredditName: "test1"},
+		},
+	}
+	require.Equal(t, "test1", crosspost.OriginSubreddit())
+
+	blob, err := readFileContents("../testdata/post/crosspost.json")
+	require.NoError(t, err)
+
+	post := new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+	require.Equal(t, "test4", post.SubredditName)
+	// only 3 levels of crossposts are decoded by default, so the origin isn't known
+	require.Empty(t, post.OriginSubreddit())
+
+	defer func(depth int) { MaxCrosspostDepth = depth }(MaxCrosspostDepth)
+	MaxCrosspostDepth = 4
+
+	post = new(Post)
+	err = json.Unmarshal([]byte(blob), post)
+	require.NoError(t, err)
+	require.Equal(t, "test0", post.OriginSubreddit())
+}
+
+func TestSubreddit_DefaultListingPath(t *testing.T) {
+	require.Equal(t, "/r/golang/hot", (&Subreddit{Name: "golang", Type: "public"}).DefaultListingPath())
+	require.Equal(t, "/user/v_95/posts", (&Subreddit{Name: "u_v_95", Type: "user"}).DefaultListingPath())
+	require.Equal(t, "/user/v_95/posts", (&Subreddit{Name: "u_v_95"}).DefaultListingPath())
+}
+
+func TestSubreddit_SupportsModmail(t *testing.T) {
+	require.True(t, (&Subreddit{Name: "golang", Type: "public"}).SupportsModmail())
+	require.True(t, (&Subreddit{Name: "test", Type: "restricted"}).SupportsModmail())
+	require.False(t, (&Subreddit{Name: "u_v_95", Type: "user"}).SupportsModmail())
+	require.False(t, (&Subreddit{Name: "u_v_95"}).SupportsModmail())
+}
+
+func TestPost_ApproxDownvotes(t *testing.T) {
+	post := &Post{Score: 100, UpvoteRatio: 0.75}
+	require.Equal(t, 150, post.ApproxUpvotes())
+	require.Equal(t, 50, post.ApproxDownvotes())
+
+	post = &Post{Score: 253, UpvoteRatio: 0.99}
+	require.Equal(t, 256, post.ApproxUpvotes())
+	require.Equal(t, 3, post.ApproxDownvotes())
+
+	post = &Post{Score: 10, UpvoteRatio: 1}
+	require.Equal(t, 10, post.ApproxUpvotes())
+	require.Equal(t, 0, post.ApproxDownvotes())
+
+	post = &Post{Score: -5, UpvoteRatio: 0.2}
+	require.Equal(t, 2, post.ApproxUpvotes())
+	require.Equal(t, 7, post.ApproxDownvotes())
+
+	// nothing can be estimated from a ratio of 0.5
+	post = &Post{Score: 0, UpvoteRatio: 0.5}
+	require.Equal(t, 0, post.ApproxUpvotes())
+	require.Equal(t, 0, post.ApproxDownvotes())
+}
+
+func TestThings_Find(t *testing.T) {
+	blob, err := readFileContents("../testdata/listings/posts-comments-subreddits.json")
+	require.NoError(t, err)
+
+	root := new(thing)
+	err = json.Unmarshal([]byte(blob), root)
+	require.NoError(t, err)
+	l, _ := root.Listing()
+	tt := l.things
+	require.NotEmpty(t, tt.Posts)
+	require.NotEmpty(t, tt.Comments)
+
+	v, ok := tt.Find(tt.Comments[0].FullID)
+	require.True(t, ok)
+	require.Equal(t, "t1", v.Kind())
+	comment, ok := v.(*Comment)
+	require.True(t, ok)
+	require.Equal(t, tt.Comments[0], comment)
+
+	v, ok = tt.Find(tt.Posts[0].FullID)
+	require.True(t, ok)
+	require.Equal(t, "t3", v.Kind())
+	require.Equal(t, tt.Posts[0], v)
+
+	tt.Users = []*User{{ID: "164ab8", Name: "v_95"}}
+	v, ok = tt.Find("t2_164ab8")
+	require.True(t, ok)
+	require.Equal(t, "v_95", v.(*User).Name)
+
+	v, ok = tt.Find("t3_missing")
+	require.False(t, ok)
+	require.Nil(t, v)
+}
+
+func TestComment_CollapseReasonCode(t *testing.T) {
+	comment := new(Comment)
+	err := json.Unmarshal([]byte(`{
+		"id": "abc123",
+		"score": -12,
+		"collapsed": true,
+		"collapsed_reason": "comment score below threshold",
+		"collapsed_reason_code": "LOW_SCORE",
+		"replies": ""
+	}`), comment)
+	require.NoError(t, err)
+	require.Equal(t, CollapseReasonLowScore, comment.CollapseReasonCode())
+
+	comment = new(Comment)
+	err = json.Unmarshal([]byte(`{"id": "abc123", "collapsed_reason_code": null, "replies": ""}`), comment)
+	require.NoError(t, err)
+	require.Empty(t, comment.CollapseReasonCode())
+}
+
+func TestSubreddit_ActivityLevel(t *testing.T) {
+	newSubreddit := func(active, subscribers int) *Subreddit {
+		return &Subreddit{ActiveUserCount: Int(active), Subscribers: subscribers}
+	}
+
+	require.Equal(t, "unknown", (&Subreddit{Subscribers: 1000}).ActivityLevel())
+	require.Equal(t, "dead", newSubreddit(0, 1000).ActivityLevel())
+	require.Equal(t, "dead", newSubreddit(5, 100000).ActivityLevel())
+	require.Equal(t, "quiet", newSubreddit(10, 100000).ActivityLevel())
+	require.Equal(t, "quiet", newSubreddit(99, 100000).ActivityLevel())
+	require.Equal(t, "active", newSubreddit(100, 100000).ActivityLevel())
+	require.Equal(t, "bustling", newSubreddit(1000, 100000).ActivityLevel())
+	require.Equal(t, "bustling", newSubreddit(3, 0).ActivityLevel())
+}
+
+func TestPost_FlairCategory(t *testing.T) {
+	mapping := map[string]string{
+		"c4edd5ce-40e8-11e7-b814-0ef91bd65558": "discussion",
+		"9b12fc60-ff01-11e3-b179-12313b0a9e38": "news",
+	}
+
+	require.Equal(t, "news", (&Post{LinkFlairID: "9b12fc60-ff01-11e3-b179-12313b0a9e38"}).FlairCategory(mapping))
+	require.Equal(t, "", (&Post{LinkFlairID: "024b2b66-05ca-11e1-96f4-12313d096aae"}).FlairCategory(mapping))
+	require.Equal(t, "", new(Post).FlairCategory(mapping))
+	require.Equal(t, "", (&Post{LinkFlairID: "9b12fc60-ff01-11e3-b179-12313b0a9e38"}).FlairCategory(nil))
+}
+
+func TestThings_CreatedRange(t *testing.T) {
+	first := time.Date(2020, 7, 18, 10, 0, 0, 0, time.UTC)
+	last := time.Date(2020, 7, 20, 8, 30, 0, 0, time.UTC)
+
+	tt := things{
+		Posts: []*Post{
+			{FullID: "t3_a", Created: &Timestamp{last}},
+			{FullID: "t3_b"},
+		},
+		Comments: []*Comment{
+			{FullID: "t1_a", Created: &Timestamp{first}},
+			{FullID: "t1_b", Created: &Timestamp{first.Add(time.Hour)}},
+		},
+	}
+
+	earliest, latest := tt.CreatedRange()
+	require.Equal(t, first, earliest)
+	require.Equal(t, last, latest)
+
+	earliest, latest = things{Posts: []*Post{{FullID: "t3_b"}}}.CreatedRange()
+	require.True(t, earliest.IsZero())
+	require.True(t, latest.IsZero())
+}
+
+func TestDecodeThingsCaptureExtra(t *testing.T) {
+	blob := []byte(`[
+		{"kind": "t3", "data": {"id": "abc123", "title": "Title", "new_field": {"a": 1}, "other_field": "x",
+			"crosspost_parent_list": [{"id": "xyz789", "parent_field": 1}]}},
+		{"kind": "t1", "data": {"id": "def456", "body": "Comment", "depth": 2, "new_field": true, "replies": {"kind": "Listing", "data": {"children": [
+			{"kind": "more", "data": {"id": "m0", "children": ["m0"]}},
+			{"kind": "t1", "data": {"id": "ghi789", "depth": 3, "replies": "", "reply_field": "y"}}
+		]}}}}
+	]`)
+
+	var tt things
+	err := json.Unmarshal(blob, &tt)
+	require.NoError(t, err)
+	require.Nil(t, tt.Posts[0].Extra)
+	require.Nil(t, tt.Comments[0].Extra)
+
+	tt, err = DecodeThingsCaptureExtra(blob)
+	require.NoError(t, err)
+
+	require.Equal(t, "Title", tt.Posts[0].Title)
+	require.Equal(t, map[string]json.RawMessage{
+		"new_field":   json.RawMessage(`{"a": 1}`),
+		"other_field": json.RawMessage(`"x"`),
+	}, tt.Posts[0].Extra)
+	require.Equal(t, map[string]json.RawMessage{
+		"parent_field": json.RawMessage(`1`),
+	}, tt.Posts[0].CrosspostParentList[0].Extra)
+
+	require.Equal(t, 2, tt.Comments[0].Depth())
+	require.Equal(t, map[string]json.RawMessage{
+		"new_field": json.RawMessage(`true`),
+	}, tt.Comments[0].Extra)
+	require.Equal(t, map[string]json.RawMessage{
+		"reply_field": json.RawMessage(`"y"`),
+	}, tt.Comments[0].Replies.Comments[0].Extra)
+
+	_, err = DecodeThingsCaptureExtra([]byte(`{}`))
+	require.Error(t, err)
+}
+
+func TestThings_Trophies(t *testing.T) {
+	blob, err := readFileContents("../testdata/user/trophies.json")
+	require.NoError(t, err)
+
+	var things things
+	err = json.Unmarshal([]byte("["+blob+"]"), &things)
+	require.NoError(t, err)
+	require.Equal(t, expectedTrophies, things.Trophies)
+}
+
+func TestThings_Relationships(t *testing.T) {
+	blob, err := readFileContents("../testdata/account/friends.json")
+	require.NoError(t, err)
+
+	var decoded things
+	err = json.Unmarshal([]byte(blob), &decoded)
+	require.NoError(t, err)
+	require.Len(t, decoded.Relationships, len(expectedRelationships))
+	for i, relationship := range decoded.Relationships {
+		require.Equal(t, expectedRelationships[i], *relationship)
+	}
+
+	b, err := json.Marshal(decoded)
+	require.NoError(t, err)
+
+	var redecoded things
+	err = json.Unmarshal(b, &redecoded)
+	require.NoError(t, err)
+	require.Equal(t, decoded.Relationships, redecoded.Relationships)
+}
+
+func TestSubreddit_Snapshot(t *testing.T) {
+	now := time.Date(2020, 7, 18, 12, 0, 0, 0, time.UTC)
+	subreddit := &Subreddit{FullID: "t5_2qh23", Subscribers: 8128, ActiveUserCount: Int(42)}
+
+	snapshot := subreddit.Snapshot(now)
+	require.Equal(t, SubredditSnapshot{
+		FullID:      "t5_2qh23",
+		Subscribers: 8128,
+		ActiveUsers: Int(42),
+		At:          now,
+	}, snapshot)
+
+	// later changes to the subreddit don't affect the snapshot
+	*subreddit.ActiveUserCount = 100
+	require.Equal(t, 42, *snapshot.ActiveUsers)
+
+	subreddit.ActiveUserCount = nil
+	require.Nil(t, subreddit.Snapshot(now).ActiveUsers)
+}
+
+func TestPost_CommentGate(t *testing.T) {
+	allowed, reason := (&Post{}).CommentGate()
+	require.True(t, allowed)
+	require.Empty(t, reason)
+
+	allowed, reason = (&Post{Locked: true}).CommentGate()
+	require.False(t, allowed)
+	require.Equal(t, "locked", reason)
+
+	allowed, reason = (&Post{Archived: true}).CommentGate()
+	require.False(t, allowed)
+	require.Equal(t, "archived", reason)
+
+	allowed, reason = (&Post{Locked: true, Archived: true}).CommentGate()
+	require.False(t, allowed)
+	require.Equal(t, "locked", reason)
+}
+
+func TestMapPosts(t *testing.T) {
+	posts := []*Post{
+		{ID: "1", Title: "  first  "},
+		{ID: "2", Title: "second", NSFW: true},
+		{ID: "3", Title: "third"},
+	}
+
+	mapped := MapPosts(posts, func(p *Post) *Post {
+		if p.NSFW {
+			return nil
+		}
+		p.Title = strings.TrimSpace(p.Title)
+		return p
+	})
+	require.Len(t, mapped, 2)
+	require.Equal(t, "first", mapped[0].Title)
+	require.Equal(t, "third", mapped[1].Title)
+
+	// the original posts are left untouched
+	require.Equal(t, "  first  ", posts[0].Title)
+	require.NotSame(t, posts[0], mapped[0])
+
+	require.Empty(t, MapPosts(nil, func(p *Post) *Post { return p }))
+}
+
+func TestMapComments(t *testing.T) {
+	comments := []*Comment{
+		{ID: "1", Body: "Hello"},
+		{ID: "2", Body: "World"},
+	}
+
+	mapped := MapComments(comments, func(c *Comment) *Comment {
+		c.Body = strings.ToLower(c.Body)
+		return c
+	})
+	require.Len(t, mapped, 2)
+	require.Equal(t, "hello", mapped[0].Body)
+	require.Equal(t, "world", mapped[1].Body)
+
+	require.Equal(t, "Hello", comments[0].Body)
+	require.Equal(t, "World", comments[1].Body)
+}
+
+func TestComment_QuotedText(t *testing.T) {
+	comment := &Comment{Body: "> this is wrong\n\nNo it isn't."}
+	require.Equal(t, []string{"this is wrong"}, comment.QuotedText())
+
+	comment.Body = ">first line\n> second line\n\nreply to the first quote\n\n> another quote\n\nreply to the second quote"
+	require.Equal(t, []string{"first line\nsecond line", "another quote"}, comment.QuotedText())
+
+	comment.Body = "no quotes here, just a 2 > 1 comparison"
+	require.Nil(t, comment.QuotedText())
+}
+
+func TestSubreddit_IsNew(t *testing.T) {
+	now := time.Date(2020, 7, 18, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	subreddit := &Subreddit{Created: &Timestamp{now.Add(-week + time.Second)}}
+	require.True(t, subreddit.IsNew(week, now))
+
+	subreddit.Created = &Timestamp{now.Add(-week)}
+	require.False(t, subreddit.IsNew(week, now))
+
+	subreddit.Created = &Timestamp{now.Add(-week - time.Second)}
+	require.False(t, subreddit.IsNew(week, now))
+
+	subreddit.Created = nil
+	require.False(t, subreddit.IsNew(week, now))
+}
+
+func TestAuthorPostedYoungAccount(t *testing.T) {
+	created := time.Date(2020, 7, 18, 12, 0, 0, 0, time.UTC)
+	user := &User{Name: "test", Created: &Timestamp{created}}
+	day := 24 * time.Hour
+
+	post := &Post{Author: "test", Created: &Timestamp{created.Add(time.Hour)}}
+	require.True(t, AuthorPostedYoungAccount(post, user, day))
+
+	post.Created = &Timestamp{created.Add(30 * day)}
+	require.False(t, AuthorPostedYoungAccount(post, user, day))
+
+	// the user isn't the author of the post
+	post.Created = &Timestamp{created.Add(time.Hour)}
+	post.Author = "someone_else"
+	require.False(t, AuthorPostedYoungAccount(post, user, day))
+
+	post.Author = "test"
+	user.Created = nil
+	require.False(t, AuthorPostedYoungAccount(post, user, day))
+}
+
+func TestMore_EstimatedComments(t *testing.T) {
+	more := &More{Count: 12, Children: []string{"abc", "def"}}
+	require.Equal(t, 12, more.EstimatedComments())
+
+	more.Count = 0
+	require.Equal(t, 2, more.EstimatedComments())
+
+	require.Equal(t, 0, new(More).EstimatedComments())
+}
+
+func TestPost_WasEdited(t *testing.T) {
+	post := new(Post)
+	err := json.Unmarshal([]byte(`{"edited": false}`), post)
+	require.NoError(t, err)
+	require.False(t, post.WasEdited())
+	_, ok := post.EditedAt()
+	require.False(t, ok)
+
+	err = json.Unmarshal([]byte(`{"edited": 1609459200}`), post)
+	require.NoError(t, err)
+	require.True(t, post.WasEdited())
+	editedAt, ok := post.EditedAt()
+	require.True(t, ok)
+	require.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), editedAt)
+
+	require.False(t, new(Post).WasEdited())
+}
+
+func TestComment_WasEdited(t *testing.T) {
+	comment := new(Comment)
+	err := json.Unmarshal([]byte(`{"edited": false}`), comment)
+	require.NoError(t, err)
+	require.False(t, comment.WasEdited())
+
+	err = json.Unmarshal([]byte(`{"edited": 1609459200}`), comment)
+	require.NoError(t, err)
+	require.True(t, comment.WasEdited())
+	editedAt, ok := comment.EditedAt()
+	require.True(t, ok)
+	require.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), editedAt)
+}
+
+func TestComment_CodeBlocks(t *testing.T) {
+	comment := &Comment{Body: "Try this:\n\n```go\nfmt.Println(\"hello\")\n\nfmt.Println(\"world\")\n```\n\nIt should work."}
+	require.Equal(t, []string{"fmt.Println(\"hello\")\n\nfmt.Println(\"world\")"}, comment.CodeBlocks())
+
+	comment.Body = "Try this:\n\n    if err != nil {\n        return err\n    }\n\n    return nil\n\nOr this:\n\n\tpanic(err)\n"
+	require.Equal(t, []string{
+		"if err != nil {\n    return err\n}\n\nreturn nil",
+		"panic(err)",
+	}, comment.CodeBlocks())
+
+	comment.Body = "~~~\nls -la\n~~~\n\n    cd ..\n"
+	require.Equal(t, []string{"ls -la", "cd .."}, comment.CodeBlocks())
+
+	// indented lines right after a paragraph are not a code block
+	comment.Body = "first line\n    continued"
+	require.Nil(t, comment.CodeBlocks())
+}
+
+func TestSubreddit_FeedPath(t *testing.T) {
+	subreddit := &Subreddit{Name: "golang"}
+	require.Equal(t, "/r/golang/hot.json", subreddit.FeedPath("hot"))
+	require.Equal(t, "/r/golang/hot.json", subreddit.FeedPath(""))
+	require.Equal(t, "/r/golang/new.json", subreddit.FeedPath("new"))
+	require.Equal(t, "/r/golang/top.json?t=week", subreddit.FeedPath("top-week"))
+	require.Equal(t, "/r/golang/controversial.json?t=all", subreddit.FeedPath("controversial-all"))
+}
+
+func TestPost_Tags(t *testing.T) {
+	require.Nil(t, (&Post{}).Tags())
+	require.Equal(t, []string{"NSFW"}, (&Post{NSFW: true}).Tags())
+	require.Equal(t, []string{"Spoiler", "OC"}, (&Post{Spoiler: true, IsOC: true}).Tags())
+	require.Equal(t, []string{"NSFW", "Spoiler", "OC"}, (&Post{NSFW: true, Spoiler: true, IsOC: true}).Tags())
+}
+
+func TestGroupCommentsByPost(t *testing.T) {
+	c1 := &Comment{ID: "c1", PostID: "t3_abc123"}
+	c2 := &Comment{ID: "c2", Permalink: "/r/test/comments/def456/title/c2/"}
+	c3 := &Comment{ID: "c3", PostID: "t3_abc123"}
+	c4 := &Comment{ID: "c4"}
+
+	require.Equal(t, map[string][]*Comment{
+		"t3_abc123": {c1, c3},
+		"t3_def456": {c2},
+	}, GroupCommentsByPost([]*Comment{c1, c2, c3, c4}))
+
+	require.Empty(t, GroupCommentsByPost(nil))
+}
+
+func TestIsCakeDayPost(t *testing.T) {
+	author := &User{Name: "test", Created: &Timestamp{time.Date(2016, 7, 18, 20, 0, 0, 0, time.UTC)}}
+	now := time.Date(2020, 7, 19, 0, 0, 0, 0, time.UTC)
+
+	post := &Post{Created: &Timestamp{time.Date(2020, 7, 18, 9, 30, 0, 0, time.UTC)}}
+	require.True(t, IsCakeDayPost(post, author, now))
+
+	post.Created = &Timestamp{time.Date(2020, 7, 17, 9, 30, 0, 0, time.UTC)}
+	require.False(t, IsCakeDayPost(post, author, now))
+
+	// the day the account was created isn't a cake day
+	post.Created = &Timestamp{time.Date(2016, 7, 18, 21, 0, 0, 0, time.UTC)}
+	require.False(t, IsCakeDayPost(post, author, now))
+
+	// a post without a creation time is assumed to be posted now
+	post.Created = nil
+	require.False(t, IsCakeDayPost(post, author, now))
+	require.True(t, IsCakeDayPost(post, author, now.Add(-time.Hour)))
+
+	leapAuthor := &User{Created: &Timestamp{time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC)}}
+	require.True(t, IsCakeDayPost(&Post{Created: &Timestamp{time.Date(2019, 2, 28, 12, 0, 0, 0, time.UTC)}}, leapAuthor, now))
+	require.True(t, IsCakeDayPost(&Post{Created: &Timestamp{time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC)}}, leapAuthor, now))
+	require.False(t, IsCakeDayPost(&Post{Created: &Timestamp{time.Date(2020, 2, 28, 12, 0, 0, 0, time.UTC)}}, leapAuthor, now))
+
+	require.False(t, IsCakeDayPost(post, &User{}, now))
+}
+
+func TestEncodeThing(t *testing.T) {
+	created := &Timestamp{time.Date(2020, 7, 18, 20, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		kind  string
+		thing Thing
+	}{
+		{"t1", &Comment{ID: "c0", FullID: "t1_c0", Body: "test comment", Created: created}},
+		{"more", &More{ID: "c1", FullID: "t1_c1", ParentID: "t1_c0", Count: 2, Children: []string{"c1", "c2"}}},
+		{"t4", &Message{ID: "m0", FullID: "t4_m0", Subject: "test subject", Created: created}},
+		{"t2", &User{ID: "u0", Name: "test", Created: created}},
+		{"t3", &Post{ID: "p0", FullID: "t3_p0", Title: "test post", Created: created}},
+		{"t5", &Subreddit{ID: "s0", FullID: "t5_s0", Name: "test", Created: created}},
+		{"LiveUpdateEvent", &LiveThread{ID: "l0", FullID: "LiveUpdateEvent_l0", Title: "test thread", Created: created}},
+		{"LiveUpdate", &LiveThreadUpdate{ID: "l1", FullID: "LiveUpdate_l1", Body: "test update", Created: created}},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, EncodeThing(&buf, test.thing), test.kind)
+
+		decoded := new(thing)
+		require.NoError(t, json.Unmarshal(buf.Bytes(), decoded), test.kind)
+		require.Equal(t, test.kind, decoded.Kind)
+		require.Equal(t, test.thing, decoded.Data, test.kind)
+	}
+
+	var buf bytes.Buffer
+	require.EqualError(t, EncodeThing(&buf, nil), "thing: cannot be nil")
+	require.EqualError(t, EncodeThing(&buf, (*Post)(nil)), "thing: cannot be nil")
+	require.EqualError(t, EncodeThing(&buf, unknownThing{}), `thing: unsupported kind "unknown"`)
+	require.Zero(t, buf.Len())
+}
+
+type unknownThing struct{}
+
+func (unknownThing) Kind() string { return "unknown" }
+
+func TestThings_GroupByURL(t *testing.T) {
+	tt := things{Posts: []*Post{
+		{ID: "p0", URL: "https://www.example.com/article/?utm_source=reddit&id=1"},
+		{ID: "p1", URL: "https://i.redd.it/abc123.jpg"},
+		{ID: "p2", URL: "http://example.com/article?id=1&fbclid=abc#comments"},
+		{ID: "p3", URL: "https://www.reddit.com/r/test/comments/p3/test/", IsSelfPost: true},
+		{ID: "p4", URL: "https://example.com/article?id=2"},
+	}}
+
+	groups := tt.GroupByURL()
+	require.Len(t, groups, 3)
+	require.Equal(t, []*Post{tt.Posts[0], tt.Posts[2]}, groups["example.com/article?id=1"])
+	require.Equal(t, []*Post{tt.Posts[1]}, groups["i.redd.it/abc123.jpg"])
+	require.Equal(t, []*Post{tt.Posts[4]}, groups["example.com/article?id=2"])
+
+	require.Empty(t, things{}.GroupByURL())
+}
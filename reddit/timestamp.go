@@ -27,7 +27,9 @@ func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 	str := string(data)
 
 	// "edited" for posts and comments is either false, or a timestamp.
-	if str == "false" {
+	// Any boolean, like null, means there is no timestamp.
+	switch str {
+	case "false", "true", "null":
 		return
 	}
 
@@ -45,3 +47,30 @@ func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 func (t Timestamp) Equal(u Timestamp) bool {
 	return t.Time.Equal(u.Time)
 }
+
+// AddDuration returns a new timestamp of t+d.
+// If t is nil, it returns nil.
+// Unlike Add, which is promoted from time.Time, it returns a *Timestamp.
+func (t *Timestamp) AddDuration(d time.Duration) *Timestamp {
+	if t == nil {
+		return nil
+	}
+	return &Timestamp{t.Time.Add(d)}
+}
+
+// SubTimestamp returns the duration t-other.
+// If either timestamp is nil, it returns 0.
+func (t *Timestamp) SubTimestamp(other *Timestamp) time.Duration {
+	if t == nil || other == nil {
+		return 0
+	}
+	return t.Time.Sub(other.Time)
+}
+
+// timeOf returns the time of t, or the zero time if t is nil.
+func timeOf(t *Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
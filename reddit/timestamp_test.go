@@ -58,6 +58,11 @@ func TestTimestamp_Unmarshal(t *testing.T) {
 		{"Mismatch", referenceTimeStr, Timestamp{}, false, false},
 		{"MismatchUnix", `0`, Timestamp{}, false, false},
 		{"Invalid", `"asdf"`, Timestamp{referenceTime}, true, false},
+		{"EditedFalse", `false`, Timestamp{}, false, true},
+		{"EditedTrue", `true`, Timestamp{}, false, true},
+		{"Null", `null`, Timestamp{}, false, true},
+		{"EditedUnix", `1609459200`, Timestamp{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}, false, true},
+		{"EditedUnixFloat", `1609459200.0`, Timestamp{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}, false, true},
 	}
 	for _, tc := range testCases {
 		var got Timestamp
@@ -180,3 +185,70 @@ func TestWrappedTimstamp_MarshalReflexivity(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestamp_AddDuration(t *testing.T) {
+	ts := &Timestamp{referenceTime}
+
+	got := ts.AddDuration(time.Hour * 24)
+	if want := referenceTime.Add(time.Hour * 24); !got.Time.Equal(want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	if !ts.Time.Equal(referenceTime) {
+		t.Fatalf("receiver was modified: %v", ts)
+	}
+
+	var nilTimestamp *Timestamp
+	if got := nilTimestamp.AddDuration(time.Hour); got != nil {
+		t.Fatalf("got=%v, want=nil", got)
+	}
+}
+
+func TestTimestamp_SubTimestamp(t *testing.T) {
+	start := &Timestamp{referenceTime}
+	end := &Timestamp{referenceTime.Add(time.Hour * 72)}
+
+	testCases := []struct {
+		desc string
+		t    *Timestamp
+		u    *Timestamp
+		want time.Duration
+	}{
+		{"Positive", end, start, time.Hour * 72},
+		{"Negative", start, end, -time.Hour * 72},
+		{"NilReceiver", nil, start, 0},
+		{"NilArgument", start, nil, 0},
+	}
+	for _, tc := range testCases {
+		if got := tc.t.SubTimestamp(tc.u); got != tc.want {
+			t.Fatalf("%s: got=%v, want=%v", tc.desc, got, tc.want)
+		}
+	}
+
+	// the methods promoted from time.Time are still available
+	if got := end.Sub(start.Time); got != time.Hour*72 {
+		t.Fatalf("got=%v, want=%v", got, time.Hour*72)
+	}
+	if got := start.Add(time.Hour); !got.Equal(referenceTime.Add(time.Hour)) {
+		t.Fatalf("got=%v, want=%v", got, referenceTime.Add(time.Hour))
+	}
+}
+
+func TestTimestamp_UnmarshalEdited(t *testing.T) {
+	var post struct {
+		Edited *Timestamp `json:"edited"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"edited": false}`), &post); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if post.Edited == nil || !post.Edited.IsZero() {
+		t.Fatalf("got=%v, want zero timestamp", post.Edited)
+	}
+
+	if err := json.Unmarshal([]byte(`{"edited": 1609459200.0}`), &post); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if want := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC); !post.Edited.Time.Equal(want) {
+		t.Fatalf("got=%v, want=%v", post.Edited, want)
+	}
+}
@@ -56,6 +56,13 @@ type Trophy struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+
+	// ID of the award the trophy was granted for, if any.
+	AwardID string `json:"award_id"`
+	IconURL string `json:"icon_70"`
+	// Link to what the trophy was granted for, e.g. a post.
+	URL       string     `json:"url"`
+	GrantedAt *Timestamp `json:"granted_at,omitempty"`
 }
 
 // Get returns information about the user.
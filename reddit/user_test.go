@@ -72,6 +72,13 @@ var expectedPost = &Post{
 	Author:   "v_95",
 	AuthorID: "t2_164ab8",
 
+	LinkFlairID: "c4edd5ce-40e8-11e7-b814-0ef91bd65558",
+
+	Thumbnail: "self",
+
+	Awardings:        []*Award{},
+	IsRobotIndexable: true,
+
 	IsSelfPost: true,
 }
 
@@ -84,9 +91,12 @@ var expectedComment = &Comment{
 	ParentID:  "t3_d7ejpn",
 	Permalink: "/r/apple/comments/d7ejpn/im_giving_away_an_iphone_11_pro_to_a_commenter_at/f0zsa37/",
 
-	Body:     "Thank you!",
-	Author:   "v_95",
-	AuthorID: "t2_164ab8",
+	Body:                "Thank you!",
+	Author:              "v_95",
+	AuthorID:            "t2_164ab8",
+	AuthorFlairRichText: []FlairSegment{},
+
+	Awardings: []*Award{},
 
 	SubredditName:         "apple",
 	SubredditNamePrefixed: "r/apple",
@@ -122,11 +132,14 @@ var expectedTrophies = []*Trophy{
 		ID:          "",
 		Name:        "Three-Year Club",
 		Description: "",
+		IconURL:     "https://www.redditstatic.com/awards2/3_year_club-70.png",
 	},
 	{
 		ID:          "1q1tez",
 		Name:        "Verified Email",
 		Description: "",
+		AwardID:     "o",
+		IconURL:     "https://www.redditstatic.com/awards2/verified_email-70.png",
 	},
 }
 
@@ -136,12 +149,15 @@ var expectedUserSubreddits = []*Subreddit{
 		FullID:  "t5_3kefx",
 		Created: &Timestamp{time.Date(2017, 5, 11, 16, 37, 16, 0, time.UTC)},
 
-		URL:          "/user/nickofnight/",
-		Name:         "u_nickofnight",
-		NamePrefixed: "u/nickofnight",
-		Title:        "nickofnight",
-		Description:  "Stories written for Writing Prompts, NoSleep, and originals. Current series: The Carnival of Night ",
-		Type:         "user",
+		URL:             "/user/nickofnight/",
+		Name:            "u_nickofnight",
+		NamePrefixed:    "u/nickofnight",
+		Title:           "nickofnight",
+		Description:     "Stories written for Writing Prompts, NoSleep, and originals. Current series: The Carnival of Night ",
+		DescriptionHTML: "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;Stories written for Writing Prompts, NoSleep, and originals. Current series: The Carnival of Night &lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		SidebarMD:       "Stories from Writing Prompts, and a carefully curated selection of other works.",
+		SidebarHTML:     "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;Stories from Writing Prompts, and a carefully curated selection of other works.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
+		Type:            "user",
 	},
 	{
 		ID:      "3knn1",
@@ -153,6 +169,7 @@ var expectedUserSubreddits = []*Subreddit{
 		NamePrefixed:         "u/shittymorph",
 		Title:                "shittymorph",
 		Description:          "In nineteen ninety eight the undertaker threw mankind off hеll in a cell, and plummeted sixteen feet through an announcer's table.",
+		DescriptionHTML:      "&lt;!-- SC_OFF --&gt;&lt;div class=\"md\"&gt;&lt;p&gt;In nineteen ninety eight the undertaker threw mankind off hеll in a cell, and plummeted sixteen feet through an announcer&amp;#39;s table.&lt;/p&gt;\n&lt;/div&gt;&lt;!-- SC_ON --&gt;",
 		Type:                 "user",
 		SuggestedCommentSort: "qa",
 	},
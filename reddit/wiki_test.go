@@ -73,6 +73,11 @@ var expectedWikiPageDiscussions = []*Post{
 
 		Author:   "v_95",
 		AuthorID: "t2_164ab8",
+
+		Thumbnail: "default",
+
+		Awardings:        []*Award{},
+		IsRobotIndexable: true,
 	},
 }
 